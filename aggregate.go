@@ -0,0 +1,150 @@
+package decimal4
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+)
+
+// ErrPercentile is returned by Percentile when p is not 0 to 1.
+var ErrPercentile = errors.New("decimal4: percentile must be 0 to 1")
+
+// Aggregate functions work on slices of Decimal4, Decimal6 or Fixed values.
+// Totals are kept in 128 bits, so Mean and Median are correct when the sum exceeds int64.
+
+// Sum returns the total of values, 0 if values is empty. Panics on overflow.
+func Sum[T Decimal](values []T) T {
+	total, err := SumChecked(values)
+	if err != nil {
+		overflow("Decimal Sum", values)
+	}
+	return total
+}
+
+// SumChecked returns the total of values, or ErrOverflow if it does not fit.
+func SumChecked[T Decimal](values []T) (T, error) {
+	total, ok := sum128(values).int64()
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return T(total), nil
+}
+
+func sum128[T Decimal](values []T) int128 {
+	var total int128
+	for _, v := range values {
+		total, _ = total.add(int128From64(int64(v)))
+	}
+	return total
+}
+
+// Mean returns the average of values rounded using mode, or ErrEmpty.
+func Mean[T Decimal](values []T, mode RoundingMode) (T, error) {
+	if len(values) == 0 {
+		return 0, ErrEmpty
+	}
+	mean, _ := sum128(values).mulDivSigned(1, int64(len(values)), mode)
+	x, _ := mean.int64()
+	return T(x), nil
+}
+
+// WeightedMean returns sum(values[i] * weights[i]) / sum(weights) rounded using mode.
+// Returns ErrLength if the lengths of values and weights differ, ErrEmpty if values is empty,
+// ErrDivisionByZero if the weights sum to 0.
+func WeightedMean[T, W Decimal](values []T, weights []W, mode RoundingMode) (T, error) {
+	if len(values) != len(weights) {
+		return 0, ErrLength
+	}
+	if len(values) == 0 {
+		return 0, ErrEmpty
+	}
+	n, d := new(big.Int), new(big.Int)
+	for i, v := range values {
+		w := big.NewInt(int64(weights[i]))
+		n.Add(n, w.Mul(w, big.NewInt(int64(v))))
+		d.Add(d, big.NewInt(int64(weights[i])))
+	}
+	if d.Sign() == 0 {
+		return 0, ErrDivisionByZero
+	}
+	mean := bigQuo(n, d, mode)
+	if !mean.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return T(mean.Int64()), nil
+}
+
+// Min returns the smallest of values, or ErrEmpty.
+func Min[T Decimal](values []T) (T, error) {
+	if len(values) == 0 {
+		return 0, ErrEmpty
+	}
+	min := values[0]
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+	}
+	return min, nil
+}
+
+// Max returns the largest of values, or ErrEmpty.
+func Max[T Decimal](values []T) (T, error) {
+	if len(values) == 0 {
+		return 0, ErrEmpty
+	}
+	max := values[0]
+	for _, v := range values[1:] {
+		if v > max {
+			max = v
+		}
+	}
+	return max, nil
+}
+
+func sorted[T Decimal](values []T) []T {
+	s := append([]T(nil), values...)
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+	return s
+}
+
+// Median returns the middle value of values, or ErrEmpty.
+// For an even number of values it is the mean of the middle two, rounded using mode.
+func Median[T Decimal](values []T, mode RoundingMode) (T, error) {
+	if len(values) == 0 {
+		return 0, ErrEmpty
+	}
+	s := sorted(values)
+	mid := len(s) / 2
+	if len(s)%2 == 1 {
+		return s[mid], nil
+	}
+	return Mean(s[mid-1:mid+1], mode)
+}
+
+// Percentile returns the p (0 to 1) percentile of values, interpolating linearly
+// between the closest ranks (as spreadsheet PERCENTILE.INC), rounded using mode.
+// Returns ErrEmpty or ErrPercentile.
+func Percentile[T Decimal](values []T, p Decimal6, mode RoundingMode) (T, error) {
+	if len(values) == 0 {
+		return 0, ErrEmpty
+	}
+	if p < 0 || p > 1000000 {
+		return 0, ErrPercentile
+	}
+	s := sorted(values)
+	// rank = p * (n - 1) = ndx + frac / 10^6
+	rank := int64(p) * int64(len(s)-1)
+	ndx, frac := rank/1000000, rank%1000000
+	if frac == 0 {
+		return s[ndx], nil
+	}
+	// exact s[ndx] * 10^6 + (s[ndx+1] - s[ndx]) * frac, rounded once
+	diff, _ := int128From64(int64(s[ndx+1])).sub(int128From64(int64(s[ndx])))
+	diff, _ = diff.mulDiv(uint64(frac), 1, RoundDown)
+	x, _ := int128From64(int64(s[ndx])).mulDiv(1000000, 1, RoundDown)
+	x, _ = x.add(diff)
+	x, _ = x.mulDivSigned(1, 1000000, mode)
+	result, _ := x.int64()
+	return T(result), nil
+}
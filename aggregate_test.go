@@ -0,0 +1,167 @@
+package decimal4
+
+import "testing"
+
+func TestAggregate(t *testing.T) {
+	type input struct {
+		values              []float64
+		sum, mean, min, max float64
+		median, p25, p90    float64
+	}
+	data := []input{
+		{[]float64{500.0025, 200.0005, 299.997}, 1000, 333.3333, 200.0005, 500.0025, 299.997, 249.9988, 460.0014},
+		{[]float64{4, 1, 3, 2}, 10, 2.5, 1, 4, 2.5, 1.75, 3.7},
+		{[]float64{1, 1, 2}, 4, 1.3333, 1, 2, 1, 1, 1.8},
+		{[]float64{-1, -1, -2}, -4, -1.3333, -2, -1, -1, -1.5, -1},
+		{[]float64{.0001, .0002}, .0003, .0002, .0001, .0002, .0002, .0001, .0002},
+		{[]float64{-5.5}, -5.5, -5.5, -5.5, -5.5, -5.5, -5.5, -5.5},
+	}
+	for i, v := range data {
+		values := make([]Decimal4, len(v.values))
+		for j, x := range v.values {
+			values[j] = New(x)
+		}
+		sum := Sum(values)
+		mean, _ := Mean(values, RoundHalfUp)
+		min, _ := Min(values)
+		max, _ := Max(values)
+		median, _ := Median(values, RoundHalfUp)
+		p25, _ := Percentile(values, NewDecimal6(.25), RoundHalfUp)
+		p90, _ := Percentile(values, NewDecimal6(.9), RoundHalfUp)
+		got := []Decimal4{sum, mean, min, max, median, p25, p90}
+		want := []float64{v.sum, v.mean, v.min, v.max, v.median, v.p25, v.p90}
+		for j := range got {
+			if got[j] != New(want[j]) {
+				t.Errorf("data[%d]: result %d should be %f, but is %s", i, j, want[j], got[j])
+			}
+		}
+		if values[0] != New(v.values[0]) {
+			t.Errorf("data[%d]: values were modified", i)
+		}
+	}
+}
+
+func TestAggregateDecimal6(t *testing.T) {
+	values := []Decimal6{NewDecimal6(.000001), NewDecimal6(.000002), NewDecimal6(1.5)}
+	if sum, err := SumChecked(values); err != nil || sum != NewDecimal6(1.500003) {
+		t.Error("SumChecked should be 1.500003, but is", sum, err)
+	}
+	if mean, _ := Mean(values, RoundDown); mean != NewDecimal6(.500001) {
+		t.Error("Mean should be .500001, but is", mean)
+	}
+	if median, _ := Median(values, RoundHalfUp); median != NewDecimal6(.000002) {
+		t.Error("Median should be .000002, but is", median)
+	}
+	if p, _ := Percentile(values, 1000000, RoundHalfUp); p != NewDecimal6(1.5) {
+		t.Error("Percentile 1 should be 1.5, but is", p)
+	}
+
+	// sum overflows int64, mean does not
+	large := Decimal6(1<<63 - 1)
+	if _, err := SumChecked([]Decimal6{large, large}); err != ErrOverflow {
+		t.Error("SumChecked expected ErrOverflow, got", err)
+	}
+	if mean, err := Mean([]Decimal6{large, large - 2}, RoundHalfUp); err != nil || mean != large-1 {
+		t.Error("Mean should be", large-1, "but is", mean, err)
+	}
+	if median, _ := Median([]Decimal6{-large, -large - 1}, RoundHalfEven); median != -large-1 {
+		t.Error("Median should be", -large-1, "but is", median)
+	}
+	if p, _ := Percentile([]Decimal6{-large, large}, 500000, RoundHalfUp); p != 0 {
+		t.Error("Percentile .5 should be 0, but is", p)
+	}
+}
+
+func TestPercentileNegative(t *testing.T) {
+	type input struct {
+		values []Decimal4
+		p      float64
+		mode   RoundingMode
+		result Decimal4
+	}
+	data := []input{
+		{[]Decimal4{-5, -2}, .5, RoundHalfUp, -4}, // -3.5 units
+		{[]Decimal4{-5, -2}, .5, RoundHalfDown, -3},
+		{[]Decimal4{-5, -2}, .5, RoundHalfEven, -4},
+		{[]Decimal4{-5, -2}, .5, RoundDown, -3},
+		{[]Decimal4{-5, -2}, .5, RoundUp, -4},
+		{[]Decimal4{-5, -2}, .5, RoundFloor, -4},
+		{[]Decimal4{-5, -2}, .5, RoundCeiling, -3},
+		{[]Decimal4{-1, 2}, .5, RoundHalfUp, 1}, // .5 units
+		{[]Decimal4{-2, 1}, .5, RoundHalfUp, -1},
+		{[]Decimal4{-2, 1}, .5, RoundFloor, -1},
+		{[]Decimal4{-2, 1}, .5, RoundCeiling, 0},
+		{[]Decimal4{-10, 0}, .25, RoundDown, -7}, // -7.5 units
+		{[]Decimal4{-10, 0}, .25, RoundHalfEven, -8},
+	}
+	for i, v := range data {
+		result, err := Percentile(v.values, NewDecimal6(v.p), v.mode)
+		if err != nil || result != v.result {
+			t.Errorf("data[%d]: Percentile should be %d, but is %d %v", i, v.result, result, err)
+		}
+	}
+	// Percentile .5 is the Median for any mode
+	pairs := [][]Decimal4{{-5, -2}, {-7, 4}, {-3, 8}, {3, 8}}
+	for _, values := range pairs {
+		for mode := RoundHalfUp; mode <= RoundCeiling; mode++ {
+			p, _ := Percentile(values, NewDecimal6(.5), mode)
+			median, _ := Median(values, mode)
+			if p != median {
+				t.Errorf("%v mode %d: Percentile .5 %d should equal Median %d", values, mode, p, median)
+			}
+		}
+	}
+}
+
+func TestWeightedMean(t *testing.T) {
+	prices := []Decimal4{New(10), New(20), New(30)}
+	quantities := []Decimal4{New(1), New(2), New(3)}
+	if mean, err := WeightedMean(prices, quantities, RoundHalfUp); err != nil || mean != New(23.3333) {
+		t.Error("WeightedMean should be 23.3333, but is", mean, err)
+	}
+	rates := []Decimal6{NewDecimal6(.05), NewDecimal6(.07)}
+	balances := []Decimal4{New(1000), New(3000)}
+	if mean, _ := WeightedMean(rates, balances, RoundHalfUp); mean != NewDecimal6(.065) {
+		t.Error("WeightedMean should be .065, but is", mean)
+	}
+	if _, err := WeightedMean(prices, []Decimal4{0, 0, 0}, RoundHalfUp); err != ErrDivisionByZero {
+		t.Error("expected ErrDivisionByZero, got", err)
+	}
+	if _, err := WeightedMean(prices, quantities[:2], RoundHalfUp); err != ErrLength {
+		t.Error("expected ErrLength, got", err)
+	}
+	if _, err := WeightedMean([]Decimal4{}, []Decimal4{}, RoundHalfUp); err != ErrEmpty {
+		t.Error("expected ErrEmpty, got", err)
+	}
+}
+
+func TestAggregateErrors(t *testing.T) {
+	var empty []Decimal4
+	if Sum(empty) != 0 {
+		t.Error("Sum of empty should be 0")
+	}
+	if _, err := Mean(empty, RoundHalfUp); err != ErrEmpty {
+		t.Error("Mean expected ErrEmpty, got", err)
+	}
+	if _, err := Min(empty); err != ErrEmpty {
+		t.Error("Min expected ErrEmpty, got", err)
+	}
+	if _, err := Max(empty); err != ErrEmpty {
+		t.Error("Max expected ErrEmpty, got", err)
+	}
+	if _, err := Median(empty, RoundHalfUp); err != ErrEmpty {
+		t.Error("Median expected ErrEmpty, got", err)
+	}
+	if _, err := Percentile(empty, 0, RoundHalfUp); err != ErrEmpty {
+		t.Error("Percentile expected ErrEmpty, got", err)
+	}
+	if _, err := Percentile([]Decimal4{1}, NewDecimal6(1.01), RoundHalfUp); err != ErrPercentile {
+		t.Error("Percentile expected ErrPercentile, got", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Sum should panic on overflow")
+		}
+	}()
+	Sum([]Decimal4{1<<63 - 1, 1})
+}
@@ -0,0 +1,94 @@
+package decimal4
+
+import (
+	"errors"
+	"log"
+	"math/big"
+)
+
+// ErrAllocateWeights is returned by Allocate when a weight is negative.
+var ErrAllocateWeights = errors.New("decimal4: allocation weights must not be negative")
+
+// Allocate splits amount into shares proportional to weights, each rounded to places
+// (0 to amount.Places(), panics otherwise). Amount is first rounded to places using RoundHalfUp.
+// Shares are truncated, then the units left over go one at a time to the shares with the
+// largest remainders (earliest first), so the shares always sum exactly to the rounded amount.
+// If all weights are zero, amount is split evenly.
+// Returns ErrOverflow if the rounded amount does not fit.
+func Allocate[T Decimal](amount T, weights []T, places int) ([]T, error) {
+	return allocate(amount, weights, places, false)
+}
+
+// allocateSigned is like Allocate, but weights may be negative, as for credit lines.
+// Shares are amount * weight / sum of weights, so a negative weight gets a share of the opposite sign.
+// Returns ErrAllocateWeights if the weights sum to zero and the rounded amount is not zero.
+func allocateSigned(amount Decimal4, weights []Decimal4, places int) ([]Decimal4, error) {
+	return allocate(amount, weights, places, true)
+}
+
+// allocate is the largest remainder method for Allocate and allocateSigned.
+// Shares are computed for the magnitude of the rounded amount and a positive weight total,
+// then given the sign of the amount, so the allocation of -x is the negation of x.
+func allocate[T Decimal](amount T, weights []T, places int, signed bool) ([]T, error) {
+	if places < 0 || places > amount.Places() {
+		log.Panic("Decimal4 Allocate invalid places=", places)
+	}
+	unit := pow10[amount.Places()-places]
+	rounded, _ := mulDiv(int64(amount), 1, unit, RoundHalfUp) // |rounded| <= |amount|
+	units := big.NewInt(rounded)
+	units.Abs(units)
+
+	total := new(big.Int)
+	for _, w := range weights {
+		if w < 0 && !signed {
+			return nil, ErrAllocateWeights
+		}
+		total.Add(total, big.NewInt(int64(w)))
+	}
+	weight := func(w T) *big.Int { return big.NewInt(int64(w)) }
+	switch {
+	case total.Sign() == 0 && signed:
+		if rounded != 0 {
+			return nil, ErrAllocateWeights
+		}
+		return make([]T, len(weights)), nil
+	case total.Sign() == 0:
+		total.SetInt64(int64(len(weights))) // split evenly
+		weight = func(T) *big.Int { return big.NewInt(1) }
+	case total.Sign() < 0:
+		total.Neg(total)
+		weight = func(w T) *big.Int { return new(big.Int).Neg(big.NewInt(int64(w))) }
+	}
+
+	// floor of each share, remainders are 0 to total - 1
+	quotients := make([]*big.Int, len(weights))
+	remainders := make([]*big.Int, len(weights))
+	left := new(big.Int).Set(units) // shares sum to units
+	for i, w := range weights {
+		p := weight(w)
+		quotients[i], remainders[i] = p.DivMod(p.Mul(p, units), total, new(big.Int))
+		left.Sub(left, quotients[i])
+	}
+	for n := left.Int64(); n > 0; n-- { // left < len(weights)
+		largest := 0
+		for i, r := range remainders {
+			if r.Cmp(remainders[largest]) > 0 {
+				largest = i
+			}
+		}
+		quotients[largest].Add(quotients[largest], big.NewInt(1))
+		remainders[largest].SetInt64(-1)
+	}
+	shares := make([]T, len(weights))
+	for i, q := range quotients {
+		q.Mul(q, big.NewInt(unit))
+		if rounded < 0 {
+			q.Neg(q)
+		}
+		if !q.IsInt64() {
+			return nil, ErrOverflow
+		}
+		shares[i] = T(q.Int64())
+	}
+	return shares, nil
+}
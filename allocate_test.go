@@ -0,0 +1,90 @@
+package decimal4
+
+import "testing"
+
+func TestAllocate(t *testing.T) {
+	type input struct {
+		amount  float64
+		weights []float64
+		places  int
+		shares  []float64
+	}
+	data := []input{
+		{100, []float64{1, 1, 1}, 2, []float64{33.34, 33.33, 33.33}},
+		{-100, []float64{1, 1, 1}, 2, []float64{-33.34, -33.33, -33.33}},
+		{10, []float64{0, 0}, 2, []float64{5, 5}},
+		{.05, []float64{30, 70}, 2, []float64{.02, .03}},
+		{1, []float64{.25, .25, .5}, 0, []float64{0, 0, 1}},
+		{99.999, []float64{1, 2}, 2, []float64{33.33, 66.67}},
+		{10, []float64{1, 0, 1}, 4, []float64{5, 0, 5}},
+	}
+	for i, v := range data {
+		weights := make([]Decimal4, len(v.weights))
+		for j, w := range v.weights {
+			weights[j] = New(w)
+		}
+		shares, err := Allocate(New(v.amount), weights, v.places)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j, s := range v.shares {
+			if shares[j] != New(s) {
+				t.Errorf("data[%d]: share %d should be %f, but is %s", i, j, s, shares[j])
+			}
+		}
+	}
+	if _, err := Allocate(New(1), []Decimal4{New(1), New(-1)}, 2); err != ErrAllocateWeights {
+		t.Error("expected ErrAllocateWeights, got", err)
+	}
+	if shares, err := Allocate(New(1), []Decimal4{MaxDecimal4, MaxDecimal4, MaxDecimal4}, 2); err != nil || shares[0] != New(.34) || shares[2] != New(.33) {
+		t.Error("Allocate with 3 MaxDecimal4 weights should be .34 .33 .33, but is", shares, err)
+	}
+	if _, err := Allocate(MaxDecimal4, []Decimal4{1}, 0); err != ErrOverflow { // rounds up beyond MaxDecimal4
+		t.Error("expected ErrOverflow, got", err)
+	}
+	if shares, err := Allocate(NewDecimal6(1), []Decimal6{1, 2}, 6); err != nil || shares[0] != NewDecimal6(.333333) || shares[1] != NewDecimal6(.666667) {
+		t.Error("Allocate Decimal6 should be .333333 .666667, but is", shares, err)
+	}
+	if shares, err := Allocate(Decimal2(-1000), []Decimal2{1, 1, 1}, 1); err != nil || shares[0] != -340 || shares[1] != -330 {
+		t.Error("Allocate Decimal2 should be -3.4 -3.3 -3.3, but is", shares, err)
+	}
+	if shares, err := Allocate(New(1), []Decimal4{MaxDecimal4, MaxDecimal4}, 2); err != nil || shares[0] != New(.5) || shares[1] != New(.5) {
+		t.Error("Allocate with 2 MaxDecimal4 weights should be .50 .50, but is", shares, err)
+	}
+}
+
+func TestAllocateSigned(t *testing.T) {
+	type input struct {
+		amount  float64
+		weights []float64
+		shares  []float64
+	}
+	data := []input{
+		{.9, []float64{19, -10}, []float64{1.9, -1}},
+		{-.9, []float64{-19, 10}, []float64{-1.9, 1}},
+		{1, []float64{1, 1, 1}, []float64{.34, .33, .33}},
+		{1, []float64{2, -1, 2}, []float64{.67, -.33, .66}},
+		{0, []float64{10, -10}, []float64{0, 0}},
+	}
+	for i, v := range data {
+		weights := make([]Decimal4, len(v.weights))
+		for j, w := range v.weights {
+			weights[j] = New(w)
+		}
+		shares, err := allocateSigned(New(v.amount), weights, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j, s := range v.shares {
+			if shares[j] != New(s) {
+				t.Errorf("data[%d]: share %d should be %f, but is %s", i, j, s, shares[j])
+			}
+		}
+	}
+	if _, err := allocateSigned(New(1), []Decimal4{New(10), New(-10)}, 2); err != ErrAllocateWeights {
+		t.Error("expected ErrAllocateWeights, got", err)
+	}
+	if _, err := allocateSigned(New(100), []Decimal4{MaxDecimal4, -MaxDecimal4 + 1}, 2); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+}
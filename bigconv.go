@@ -0,0 +1,86 @@
+package decimal4
+
+import "math/big"
+
+// Rat returns the exact value of this.
+func (this Decimal4) Rat() *big.Rat {
+	return big.NewRat(int64(this), 10000)
+}
+
+// BigInt returns this in Decimal4 units (4 implied decimal places).
+func (this Decimal4) BigInt() *big.Int {
+	return big.NewInt(int64(this))
+}
+
+// BigFloat returns this as a big.Float with prec bits of precision (rounded to nearest even).
+// Exact only if this is a binary fraction (for example .5 or .25) that fits in prec bits.
+func (this Decimal4) BigFloat(prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec).SetRat(this.Rat())
+}
+
+// FromRat returns r rounded to 4 decimal places using mode, or ErrOverflow if it does not fit.
+func FromRat(r *big.Rat, mode RoundingMode) (Decimal4, error) {
+	q := bigQuo(new(big.Int).Mul(r.Num(), bigTenThou), r.Denom(), mode)
+	if !q.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return Decimal4(q.Int64()), nil
+}
+
+// FromBigInt returns scaled (in Decimal4 units, 4 implied decimal places), or ErrOverflow if it does not fit.
+func FromBigInt(scaled *big.Int) (Decimal4, error) {
+	if !scaled.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return Decimal4(scaled.Int64()), nil
+}
+
+// FromBigFloat returns f rounded to 4 decimal places using mode, or ErrOverflow if it does not fit.
+func FromBigFloat(f *big.Float, mode RoundingMode) (Decimal4, error) {
+	if f.IsInf() {
+		return 0, ErrOverflow
+	}
+	r, _ := f.Rat(nil)
+	return FromRat(r, mode)
+}
+
+// Rat returns the exact value of this.
+func (this Decimal6) Rat() *big.Rat {
+	return big.NewRat(int64(this), 1000000)
+}
+
+// BigInt returns this in Decimal6 units (6 implied decimal places).
+func (this Decimal6) BigInt() *big.Int {
+	return big.NewInt(int64(this))
+}
+
+// BigFloat returns this as a big.Float with prec bits of precision (rounded to nearest even).
+func (this Decimal6) BigFloat(prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec).SetRat(this.Rat())
+}
+
+// Decimal6FromRat returns r rounded to 6 decimal places using mode, or ErrOverflow if it does not fit.
+func Decimal6FromRat(r *big.Rat, mode RoundingMode) (Decimal6, error) {
+	q := bigQuo(new(big.Int).Mul(r.Num(), bigMillion), r.Denom(), mode)
+	if !q.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return Decimal6(q.Int64()), nil
+}
+
+// Decimal6FromBigInt returns scaled (in Decimal6 units, 6 implied decimal places), or ErrOverflow if it does not fit.
+func Decimal6FromBigInt(scaled *big.Int) (Decimal6, error) {
+	if !scaled.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return Decimal6(scaled.Int64()), nil
+}
+
+// Decimal6FromBigFloat returns f rounded to 6 decimal places using mode, or ErrOverflow if it does not fit.
+func Decimal6FromBigFloat(f *big.Float, mode RoundingMode) (Decimal6, error) {
+	if f.IsInf() {
+		return 0, ErrOverflow
+	}
+	r, _ := f.Rat(nil)
+	return Decimal6FromRat(r, mode)
+}
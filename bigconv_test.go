@@ -0,0 +1,69 @@
+package decimal4
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestBigConversions(t *testing.T) {
+	values := []Decimal4{0, 1, -1, 12345678, -98765432109, math.MaxInt64, math.MinInt64}
+	for _, v := range values {
+		if x, err := FromRat(v.Rat(), RoundDown); err != nil || x != v {
+			t.Errorf("Rat round trip of %d is %d, %v", v, x, err)
+		}
+		if x, err := FromBigInt(v.BigInt()); err != nil || x != v {
+			t.Errorf("BigInt round trip of %d is %d, %v", v, x, err)
+		}
+		if x, err := FromBigFloat(v.BigFloat(128), RoundHalfEven); err != nil || x != v {
+			t.Errorf("BigFloat round trip of %d is %d, %v", v, x, err)
+		}
+		d6 := Decimal6(v)
+		if x, err := Decimal6FromRat(d6.Rat(), RoundDown); err != nil || x != d6 {
+			t.Errorf("Decimal6 Rat round trip of %d is %d, %v", v, x, err)
+		}
+		if x, err := Decimal6FromBigInt(d6.BigInt()); err != nil || x != d6 {
+			t.Errorf("Decimal6 BigInt round trip of %d is %d, %v", v, x, err)
+		}
+		if x, err := Decimal6FromBigFloat(d6.BigFloat(128), RoundHalfEven); err != nil || x != d6 {
+			t.Errorf("Decimal6 BigFloat round trip of %d is %d, %v", v, x, err)
+		}
+	}
+
+	type input struct {
+		num, den int64
+		mode     RoundingMode
+		d4       Decimal4
+		d6       Decimal6
+	}
+	data := []input{
+		{2, 3, RoundHalfUp, 6667, 666667},
+		{-2, 3, RoundHalfUp, -6667, -666667},
+		{2, 3, RoundDown, 6666, 666666},
+		{1, 20000, RoundHalfEven, 0, 50},
+		{3, 20000, RoundHalfEven, 2, 150},
+		{-1, 3, RoundFloor, -3334, -333334},
+	}
+	for i, v := range data {
+		r := big.NewRat(v.num, v.den)
+		if x, _ := FromRat(r, v.mode); x != v.d4 {
+			t.Errorf("data[%d]: FromRat should be %d, but is %d", i, v.d4, x)
+		}
+		if x, _ := Decimal6FromRat(r, v.mode); x != v.d6 {
+			t.Errorf("data[%d]: Decimal6FromRat should be %d, but is %d", i, v.d6, x)
+		}
+	}
+
+	if _, err := FromRat(big.NewRat(math.MaxInt64, 1000), RoundDown); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+	if _, err := FromBigInt(new(big.Int).Lsh(big.NewInt(1), 63)); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+	if _, err := FromBigFloat(new(big.Float).SetInf(true), RoundDown); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+	if f, _ := New(.1).BigFloat(53).Float64(); f != .1 {
+		t.Errorf("BigFloat(53) should be .1, but is %v", f)
+	}
+}
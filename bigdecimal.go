@@ -0,0 +1,214 @@
+package decimal4
+
+import (
+	"log"
+	"math/big"
+)
+
+// BigDecimal4 has the same 4 implied decimal places and method names as Decimal4,
+// with unlimited magnitude. Use it when a Decimal4 calculation would overflow.
+// Values are immutable, methods return new values. The zero value is 0.
+type BigDecimal4 struct {
+	v *big.Int // Decimal4 units, nil = 0
+}
+
+var (
+	bigOne     = big.NewInt(1)
+	bigHundred = big.NewInt(100)
+	bigTenThou = big.NewInt(10000)
+	bigMillion = big.NewInt(1000000)
+)
+
+func (this BigDecimal4) int() *big.Int {
+	if this.v == nil {
+		return new(big.Int)
+	}
+	return this.v
+}
+
+// NewBig returns x as a BigDecimal4.
+func NewBig(x Decimal4) BigDecimal4 {
+	return BigDecimal4{big.NewInt(int64(x))}
+}
+
+// BigFromDecimal6 returns x as a BigDecimal4, or ErrInexact if x has digits in the last 2 places.
+func BigFromDecimal6(x Decimal6) (BigDecimal4, error) {
+	if x%100 != 0 {
+		return BigDecimal4{}, ErrInexact
+	}
+	return BigDecimal4{big.NewInt(int64(x / 100))}, nil
+}
+
+// BigFromInt returns scaled (in Decimal4 units, 4 implied decimal places) as a BigDecimal4.
+func BigFromInt(scaled *big.Int) BigDecimal4 {
+	return BigDecimal4{new(big.Int).Set(scaled)}
+}
+
+// BigFromRat returns r rounded to 4 decimal places using mode.
+func BigFromRat(r *big.Rat, mode RoundingMode) BigDecimal4 {
+	n := new(big.Int).Mul(r.Num(), bigTenThou)
+	return BigDecimal4{bigQuo(n, r.Denom(), mode)}
+}
+
+// BigFromFloat returns f rounded to 4 decimal places using mode.
+// Returns ErrOverflow if f is infinite.
+func BigFromFloat(f *big.Float, mode RoundingMode) (BigDecimal4, error) {
+	if f.IsInf() {
+		return BigDecimal4{}, ErrOverflow
+	}
+	r, _ := f.Rat(nil)
+	return BigFromRat(r, mode), nil
+}
+
+// ParseBig returns the value of s, for example "-1234567.8912".
+// Returns ErrParse if s is not a decimal number or has non-zero digits after 4 decimal places.
+func ParseBig(s string) (BigDecimal4, error) {
+	b, err := parseScaled(s, 4)
+	if err != nil {
+		return BigDecimal4{}, err
+	}
+	return BigDecimal4{b}, nil
+}
+
+// Decimal4 returns this as a Decimal4, or ErrOverflow if it does not fit.
+func (this BigDecimal4) Decimal4() (Decimal4, error) {
+	if !this.int().IsInt64() {
+		return 0, ErrOverflow
+	}
+	return Decimal4(this.int().Int64()), nil
+}
+
+// Decimal6 returns this as a Decimal6, or ErrOverflow if it does not fit.
+func (this BigDecimal4) Decimal6() (Decimal6, error) {
+	x := new(big.Int).Mul(this.int(), bigHundred)
+	if !x.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return Decimal6(x.Int64()), nil
+}
+
+// Int returns this in Decimal4 units (4 implied decimal places).
+func (this BigDecimal4) Int() *big.Int {
+	return new(big.Int).Set(this.int())
+}
+
+// Rat returns the exact value of this.
+func (this BigDecimal4) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(this.int(), bigTenThou)
+}
+
+// BigFloat returns this as a big.Float with prec bits of precision (rounded to nearest even).
+func (this BigDecimal4) BigFloat(prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec).SetRat(this.Rat())
+}
+
+// Cmp returns -1 if this < x, 0 if this == x, 1 if this > x.
+func (this BigDecimal4) Cmp(x BigDecimal4) int {
+	return this.int().Cmp(x.int())
+}
+
+// Sign returns -1, 0 or 1.
+func (this BigDecimal4) Sign() int {
+	return this.int().Sign()
+}
+
+// Add returns this + x.
+func (this BigDecimal4) Add(x BigDecimal4) BigDecimal4 {
+	return BigDecimal4{new(big.Int).Add(this.int(), x.int())}
+}
+
+// Sub returns this - x.
+func (this BigDecimal4) Sub(x BigDecimal4) BigDecimal4 {
+	return BigDecimal4{new(big.Int).Sub(this.int(), x.int())}
+}
+
+// Neg returns -this.
+func (this BigDecimal4) Neg() BigDecimal4 {
+	return BigDecimal4{new(big.Int).Neg(this.int())}
+}
+
+// Abs returns the absolute value of this.
+func (this BigDecimal4) Abs() BigDecimal4 {
+	return BigDecimal4{new(big.Int).Abs(this.int())}
+}
+
+// mulDiv returns this * m / d rounded using mode.
+func (this BigDecimal4) mulDiv(m, d *big.Int, mode RoundingMode) BigDecimal4 {
+	return BigDecimal4{bigQuo(new(big.Int).Mul(this.int(), m), d, mode)}
+}
+
+// Multiply returns product of this * x, rounded to 4 decimal places.
+func (this BigDecimal4) Multiply(x BigDecimal4) BigDecimal4 {
+	return this.mulDiv(x.int(), bigTenThou, RoundHalfUp)
+}
+
+// MultRound2 returns product of this * x, rounded to 2 decimal places.
+func (this BigDecimal4) MultRound2(x BigDecimal4) BigDecimal4 {
+	return this.mulDiv(x.int(), bigMillion, RoundHalfUp).mulDiv(bigHundred, bigOne, RoundHalfUp)
+}
+
+// Multiply6 returns product of this * x rounded to 4 decimal places.
+// Parameter x is type Decimal6, providing up to 6 places precision.
+func (this BigDecimal4) Multiply6(x Decimal6) BigDecimal4 {
+	return this.mulDiv(big.NewInt(int64(x)), bigMillion, RoundHalfUp)
+}
+
+// MultiplyInt returns product of this * x.
+func (this BigDecimal4) MultiplyInt(x int) BigDecimal4 {
+	return BigDecimal4{new(big.Int).Mul(this.int(), big.NewInt(int64(x)))}
+}
+
+// Divide returns quotient of this / x rounded to 4 decimal places.
+func (this BigDecimal4) Divide(x BigDecimal4) BigDecimal4 {
+	if x.Sign() == 0 {
+		log.Panic("BigDecimal4 Divide by zero, this=", this)
+	}
+	return this.mulDiv(bigTenThou, x.int(), RoundHalfUp)
+}
+
+// DivideInt returns quotient of this / x rounded to 4 decimal places.
+func (this BigDecimal4) DivideInt(x int) BigDecimal4 {
+	if x == 0 {
+		log.Panic("BigDecimal4 DivideInt by zero, this=", this)
+	}
+	return this.mulDiv(bigOne, big.NewInt(int64(x)), RoundHalfUp)
+}
+
+// CloseTo returns true if difference in values is < .1
+func (this BigDecimal4) CloseTo(x BigDecimal4) bool {
+	return this.Sub(x).Abs().int().Cmp(big.NewInt(1000)) < 0
+}
+
+// RoundPlaces returns this rounded to places (0-4) decimal places using mode.
+func (this BigDecimal4) RoundPlaces(places int, mode RoundingMode) BigDecimal4 {
+	if places < 0 || places > 4 {
+		log.Panic("BigDecimal4 RoundPlaces invalid places=", places)
+	}
+	unit := big.NewInt(pow10[4-places])
+	return this.mulDiv(bigOne, unit, mode).mulDiv(unit, bigOne, mode)
+}
+
+// Round Methods
+// Result rounded to specified number of decimal places, as Decimal4 Round0-Round3
+func (this BigDecimal4) Round0() BigDecimal4 { return this.RoundPlaces(0, RoundHalfUp) }
+func (this BigDecimal4) Round1() BigDecimal4 { return this.RoundPlaces(1, RoundHalfUp) }
+func (this BigDecimal4) Round2() BigDecimal4 { return this.RoundPlaces(2, RoundHalfUp) }
+func (this BigDecimal4) Round3() BigDecimal4 { return this.RoundPlaces(3, RoundHalfUp) }
+
+// Truncate Methods
+// Result truncated to specified number of decimal places, as Decimal4 Truncate0-Truncate3
+func (this BigDecimal4) Truncate0() BigDecimal4 { return this.RoundPlaces(0, RoundDown) }
+func (this BigDecimal4) Truncate1() BigDecimal4 { return this.RoundPlaces(1, RoundDown) }
+func (this BigDecimal4) Truncate2() BigDecimal4 { return this.RoundPlaces(2, RoundDown) }
+func (this BigDecimal4) Truncate3() BigDecimal4 { return this.RoundPlaces(3, RoundDown) }
+
+// String returns this with 4 decimal places.
+func (this BigDecimal4) String() string {
+	return formatScaled(this.int(), 4, 4)
+}
+
+// Fmt returns this formatted like Decimal4.Fmt, with width.precision
+// and comma thousands separators. Optional currency symbol is prefixed.
+func (this BigDecimal4) Fmt(widthPrecision float64, currency ...string) string {
+	return fmtScaled(this.int(), 4, widthPrecision, currency)
+}
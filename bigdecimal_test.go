@@ -0,0 +1,96 @@
+package decimal4
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestBigDecimal4(t *testing.T) {
+	// same results as Decimal4 methods within Decimal4 limits
+	data := []data{
+		{555.5555, 333.3333, 0},
+		{-7321907.6324, -32.3976, 0},
+		{.5555, .5555, 0},
+		{9999.9999, 9, 0},
+		{-1, 3, 0},
+	}
+	for i, v := range data {
+		a, b := New(v.a), New(v.b)
+		x, y := NewBig(a), NewBig(b)
+		check := []struct {
+			name string
+			big  BigDecimal4
+			d4   Decimal4
+		}{
+			{"Multiply", x.Multiply(y), a.Multiply(b)},
+			{"MultRound2", x.MultRound2(y), a.MultRound2(b)},
+			{"Divide", x.Divide(y), a.Divide(b)},
+			{"DivideInt", x.DivideInt(7), a.DivideInt(7)},
+			{"MultiplyInt", x.MultiplyInt(-3), a.MultiplyInt(-3)},
+			{"Multiply6", x.Multiply6(123456), a.Multiply6(123456)},
+			{"Round0", x.Round0(), a.Round0()},
+			{"Round1", x.Round1(), a.Round1()},
+			{"Round2", x.Round2(), a.Round2()},
+			{"Round3", x.Round3(), a.Round3()},
+			{"Truncate0", x.Truncate0(), a.Truncate0()},
+			{"Truncate2", x.Truncate2(), a.Truncate2()},
+		}
+		for _, c := range check {
+			if d4, err := c.big.Decimal4(); err != nil || d4 != c.d4 {
+				t.Errorf("data[%d]: %s should be %s, but is %s", i, c.name, c.d4, c.big)
+			}
+		}
+		if x.Fmt(20.2, Dollar) != a.Fmt(20.2, Dollar) {
+			t.Errorf("data[%d]: Fmt should be %s, but is %s", i, a.Fmt(20.2, Dollar), x.Fmt(20.2, Dollar))
+		}
+	}
+
+	// beyond Decimal4 limits
+	x := NewBig(math.MaxInt64)
+	square := x.Multiply(x)
+	if square.String() != "850705917302346158473969077842.3250" {
+		t.Errorf("Multiply should be 850705917302346158473969077842.3250, but is %s", square)
+	}
+	if _, err := square.Decimal4(); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+	if back := square.Divide(x); back.Cmp(x) != 0 {
+		t.Errorf("Divide should be %s, but is %s", x, back)
+	}
+	var zero BigDecimal4
+	if zero.Sign() != 0 || zero.Add(NewBig(1)).Cmp(NewBig(1)) != 0 || zero.String() != "0.0000" {
+		t.Error("zero value should be 0")
+	}
+}
+
+func TestBigDecimal4Conversions(t *testing.T) {
+	x, _ := ParseBig("-123456789012345678901234567890.1234")
+	if BigFromInt(x.Int()).Cmp(x) != 0 {
+		t.Error("big.Int round trip failed")
+	}
+	if BigFromRat(x.Rat(), RoundDown).Cmp(x) != 0 {
+		t.Error("big.Rat round trip failed")
+	}
+	if y, _ := BigFromFloat(x.BigFloat(200), RoundHalfUp); y.Cmp(x) != 0 {
+		t.Errorf("big.Float round trip failed: %s", y)
+	}
+	if y := BigFromRat(big.NewRat(2, 3), RoundHalfEven); y.String() != "0.6667" {
+		t.Errorf("BigFromRat should be 0.6667, but is %s", y)
+	}
+	if _, err := BigFromFloat(new(big.Float).SetInf(false), RoundHalfUp); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+	if y, err := BigFromDecimal6(-1234500); err != nil || y.String() != "-1.2345" {
+		t.Errorf("BigFromDecimal6 should be -1.2345, but is %s, %v", y, err)
+	}
+	if _, err := BigFromDecimal6(1234567); err != ErrInexact {
+		t.Error("expected ErrInexact, got", err)
+	}
+	if d6, err := NewBig(-12345).Decimal6(); err != nil || d6 != -1234500 {
+		t.Errorf("Decimal6 should be -1.234500, but is %s, %v", d6, err)
+	}
+	if _, err := NewBig(math.MaxInt64).Decimal6(); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+}
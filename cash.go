@@ -0,0 +1,48 @@
+package decimal4
+
+// CashRounding holds the smallest cash increment for currencies (ISO 4217 codes)
+// where cash payments are rounded to more than the minor unit.
+// Entries can be added or changed by the application.
+var CashRounding = map[string]Decimal4{
+	"AUD": 500,   // .05
+	"CAD": 500,   // .05, since penny removal
+	"CHF": 500,   // .05
+	"CZK": 10000, // 1
+	"DKK": 5000,  // .50
+	"HUF": 50000, // 5
+	"NOK": 10000, // 1
+	"NZD": 1000,  // .10
+	"SEK": 10000, // 1
+}
+
+// Cash returns this rounded (RoundHalfUp) to the cash increment of currency in CashRounding.
+// If currency is not in CashRounding, this is returned unchanged.
+func (this Decimal4) Cash(currency string) Decimal4 {
+	inc, found := CashRounding[currency]
+	if !found {
+		return this
+	}
+	return this.RoundToIncrement(inc, RoundHalfUp)
+}
+
+// FmtCash returns this rounded by Cash(currency), then formatted by Fmt.
+// Optional symbol is passed to Fmt, for example FmtCash(.2, "CHF", "Fr.").
+func (this Decimal4) FmtCash(widthPrecision float64, currency string, symbol ...string) string {
+	return this.Cash(currency).Fmt(widthPrecision, symbol...)
+}
+
+// MinorUnits holds the number of decimal places (ISO 4217 minor units) for currencies
+// that do not use 2. Entries can be added or changed by the application.
+var MinorUnits = map[string]int{
+	"BHD": 3, "CLP": 0, "IQD": 3, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0,
+	"KWD": 3, "LYD": 3, "OMR": 3, "PYG": 0, "TND": 3, "UGX": 0, "VND": 0,
+	"BEF": 0, "ESP": 0, "ITL": 0, "LUF": 0, // legacy currencies, see EuroRates
+}
+
+// CurrencyPlaces returns the minor units of currency from MinorUnits, or 2 if not found.
+func CurrencyPlaces(currency string) int {
+	if places, found := MinorUnits[currency]; found {
+		return places
+	}
+	return 2
+}
@@ -0,0 +1,30 @@
+package decimal4
+
+import "testing"
+
+func TestCash(t *testing.T) {
+	type input struct {
+		val      float64
+		currency string
+		result   float64
+	}
+	data := []input{
+		{1.02, "CHF", 1},
+		{1.03, "CHF", 1.05},
+		{1.074, "CAD", 1.05},
+		{-1.075, "CAD", -1.1},
+		{12.49, "SEK", 12},
+		{12.5, "SEK", 13},
+		{1.25, "DKK", 1.5},
+		{1.03, "USD", 1.03},
+	}
+	for i, v := range data {
+		result := New(v.val).Cash(v.currency)
+		if result != New(v.result) {
+			t.Errorf("data[%d]: result should be %f, but is %s", i, v.result, result)
+		}
+	}
+	if s := New(1234.03).FmtCash(.2, "CHF", "Fr."); s != "Fr.1,234.05" {
+		t.Errorf("FmtCash expected:Fr.1,234.05   got:%s", s)
+	}
+}
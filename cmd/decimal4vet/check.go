@@ -0,0 +1,279 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"math"
+	"strings"
+
+	"github.com/txjmp/decimal4"
+)
+
+const decimal4Path = "github.com/txjmp/decimal4"
+
+// Diagnostic is a problem found at Pos, in the style of golang.org/x/tools/go/analysis.
+type Diagnostic struct {
+	Pos            token.Pos
+	Message        string
+	SuggestedFixes []SuggestedFix
+}
+
+// SuggestedFix is one way to correct a Diagnostic.
+type SuggestedFix struct {
+	Message   string
+	TextEdits []TextEdit
+}
+
+// TextEdit replaces the source from Pos to End with NewText.
+type TextEdit struct {
+	Pos, End token.Pos
+	NewText  []byte
+}
+
+// checker finds unsafe constants in one type-checked package.
+type checker struct {
+	info        *types.Info
+	qualifier   string // "decimal4." or "" inside package decimal4
+	diagnostics []Diagnostic
+}
+
+// check returns the diagnostics for files of a package type-checked into info:
+//   - untyped integer constants implicitly converted to Decimal4 or Decimal6 in calls,
+//     assignments, returns and arithmetic, for example x.Multiply(5) which multiplies by .0005
+//   - Decimal4 and Decimal6 literals with leading zeros in composite literals, which are octal
+func check(pkg *types.Package, files []*ast.File, info *types.Info) []Diagnostic {
+	c := &checker{info: info}
+	for _, file := range files {
+		c.qualifier = qualifier(pkg, file)
+		ast.Inspect(file, c.visit)
+	}
+	return c.diagnostics
+}
+
+// qualifier returns the name file uses for package decimal4, with a trailing dot.
+func qualifier(pkg *types.Package, file *ast.File) string {
+	if pkg.Path() == decimal4Path {
+		return ""
+	}
+	for _, spec := range file.Imports {
+		if strings.Trim(spec.Path.Value, `"`) == decimal4Path {
+			if spec.Name != nil {
+				return spec.Name.Name + "."
+			}
+			break
+		}
+	}
+	return "decimal4."
+}
+
+func (this *checker) visit(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.CallExpr:
+		if tv, found := this.info.Types[n.Fun]; found && tv.IsType() {
+			return true // explicit conversion, Decimal4(5) is intended
+		}
+		raw := this.takesRawUnits(n.Fun)
+		for _, arg := range n.Args {
+			this.checkConstant(arg, raw)
+		}
+	case *ast.AssignStmt:
+		if n.Tok == token.MUL_ASSIGN || n.Tok == token.QUO_ASSIGN || n.Tok == token.REM_ASSIGN {
+			this.checkConstant(n.Rhs[0], true) // x *= 2 doubles x
+			break
+		}
+		for _, rhs := range n.Rhs {
+			this.checkConstant(rhs, false)
+		}
+	case *ast.ValueSpec:
+		for _, v := range n.Values {
+			this.checkConstant(v, false)
+		}
+	case *ast.ReturnStmt:
+		for _, v := range n.Results {
+			this.checkConstant(v, false)
+		}
+	case *ast.BinaryExpr:
+		if n.Op == token.MUL || n.Op == token.QUO || n.Op == token.REM {
+			this.checkFactor(n)
+			break
+		}
+		this.checkConstant(n.X, false)
+		this.checkConstant(n.Y, false)
+	case *ast.CompositeLit:
+		for _, elt := range n.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			this.checkLeadingZeros(elt)
+		}
+	}
+	return true
+}
+
+// decimalType returns "Decimal4" or "Decimal6" if t is one of them, otherwise "".
+func decimalType(t types.Type) string {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != decimal4Path {
+		return ""
+	}
+	if name := named.Obj().Name(); name == "Decimal4" || name == "Decimal6" {
+		return name
+	}
+	return ""
+}
+
+// isUntypedInt returns true if e is written as an untyped integer constant:
+// a literal, a signed literal or an untyped constant name.
+func (this *checker) isUntypedInt(e ast.Expr) bool {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.BasicLit:
+		return e.Kind == token.INT
+	case *ast.UnaryExpr:
+		return (e.Op == token.SUB || e.Op == token.ADD) && this.isUntypedInt(e.X)
+	case *ast.Ident:
+		obj, ok := this.info.Uses[e].(*types.Const)
+		if !ok {
+			return false
+		}
+		basic, ok := obj.Type().(*types.Basic)
+		return ok && basic.Info()&types.IsUntyped != 0 && basic.Info()&types.IsInteger != 0
+	}
+	return false
+}
+
+// rawUnitFuncs are the decimal4 functions documented to take Decimal4 arguments in raw units,
+// for example RoundToIncrement(500, mode) rounds to .05.
+var rawUnitFuncs = map[string]bool{"RoundToIncrement": true, "RoundToIncrementOffset": true}
+
+// takesRawUnits returns true if fun is one of rawUnitFuncs.
+func (this *checker) takesRawUnits(fun ast.Expr) bool {
+	var id *ast.Ident
+	switch fun := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return false
+	}
+	obj, ok := this.info.Uses[id].(*types.Func)
+	return ok && obj.Pkg() != nil && obj.Pkg().Path() == decimal4Path && rawUnitFuncs[obj.Name()]
+}
+
+// converted returns the value of e and "Decimal4" or "Decimal6"
+// if e is an untyped non-zero integer constant converted to one of them, otherwise name is "".
+func (this *checker) converted(e ast.Expr) (units int64, name string) {
+	tv, found := this.info.Types[e]
+	if !found || tv.Value == nil || tv.Value.Kind() != constant.Int || !this.isUntypedInt(e) {
+		return 0, ""
+	}
+	name = decimalType(tv.Type)
+	if name == "" || constant.Sign(tv.Value) == 0 {
+		return 0, ""
+	}
+	units, exact := constant.Int64Val(tv.Value)
+	if !exact {
+		return 0, ""
+	}
+	return units, name
+}
+
+// replace returns a SuggestedFix replacing e with text.
+func replace(e ast.Expr, text string) SuggestedFix {
+	return SuggestedFix{Message: "Replace with " + text, TextEdits: []TextEdit{{e.Pos(), e.End(), []byte(text)}}}
+}
+
+// checkConstant reports e if it is an untyped non-zero integer constant converted to Decimal4 or Decimal6.
+// The first fix is MustFromInt (whole units), or the explicit conversion if raw is true
+// or MustFromInt would overflow, so -fix never changes a value written in raw units.
+func (this *checker) checkConstant(e ast.Expr, raw bool) {
+	units, name := this.converted(e)
+	if name == "" {
+		return
+	}
+	means, constructor, one := decimal4.Decimal4(units).String(), "MustFromInt", int64(decimal4.MustFromInt(1))
+	if name == "Decimal6" {
+		means, constructor, one = decimal4.Decimal6(units).String(), "MustDecimal6FromInt", int64(decimal4.MustDecimal6FromInt(1))
+	}
+	text := types.ExprString(e)
+	explicit := fmt.Sprintf("%s%s(%s)", this.qualifier, name, text)
+	limit := math.MaxInt64 / one
+	if units > limit || units < -limit {
+		this.report(e, fmt.Sprintf("constant %s converted to %s is %s, use %s", text, name, means, explicit), replace(e, explicit))
+		return
+	}
+	safe := fmt.Sprintf("%s%s(%s)", this.qualifier, constructor, text)
+	if raw {
+		this.report(e, fmt.Sprintf("constant %s converted to %s is %s, use %s or %s", text, name, means, explicit, safe),
+			replace(e, explicit), replace(e, safe))
+		return
+	}
+	this.report(e, fmt.Sprintf("constant %s converted to %s is %s, use %s or %s", text, name, means, safe, explicit),
+		replace(e, safe), replace(e, explicit))
+}
+
+// checkFactor reports a constant operand of *, / or %, which scales the raw value:
+// x * 2 doubles x. The first fix is the explicit conversion, which keeps the value,
+// the second MultiplyInt or DivideInt for Decimal4.
+func (this *checker) checkFactor(n *ast.BinaryExpr) {
+	e, other := n.Y, n.X
+	units, name := this.converted(e)
+	if name == "" && n.Op == token.MUL {
+		e, other = n.X, n.Y
+		units, name = this.converted(e)
+	}
+	if name == "" {
+		this.checkConstant(n.X, true) // 4 / x or 4 % x
+		return
+	}
+	text := types.ExprString(e)
+	explicit := fmt.Sprintf("%s%s(%s)", this.qualifier, name, text)
+	method := map[token.Token]string{token.MUL: "MultiplyInt", token.QUO: "DivideInt"}[n.Op]
+	if name != "Decimal4" || method == "" || units != int64(int(units)) {
+		this.report(e, fmt.Sprintf("constant %s in %s scales the raw value, use %s", text, types.ExprString(n), explicit), replace(e, explicit))
+		return
+	}
+	operand := types.ExprString(other)
+	switch other.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.CallExpr, *ast.IndexExpr, *ast.ParenExpr:
+	default:
+		operand = "(" + operand + ")"
+	}
+	call := fmt.Sprintf("%s.%s(%s)", operand, method, text)
+	note := ""
+	if n.Op == token.QUO {
+		note = " (rounded)"
+	}
+	this.report(e, fmt.Sprintf("constant %s in %s scales the raw value, use %s%s or %s", text, types.ExprString(n), call, note, explicit),
+		replace(e, explicit), replace(n, call))
+}
+
+// report adds a Diagnostic at e.
+func (this *checker) report(e ast.Expr, message string, fixes ...SuggestedFix) {
+	this.diagnostics = append(this.diagnostics, Diagnostic{Pos: e.Pos(), Message: message, SuggestedFixes: fixes})
+}
+
+// checkLeadingZeros reports a Decimal4 or Decimal6 literal such as 0031250, which Go reads as octal.
+func (this *checker) checkLeadingZeros(e ast.Expr) {
+	lit, ok := ast.Unparen(e).(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT || !hasLeadingZero(lit.Value) {
+		return
+	}
+	if tv, found := this.info.Types[e]; found && decimalType(tv.Type) == "" {
+		return
+	}
+	fixed := strings.TrimLeft(lit.Value, "0_")
+	if fixed == "" {
+		fixed = "0"
+	}
+	this.report(lit, fmt.Sprintf("literal %s has a leading zero and is octal, write %s", lit.Value, fixed),
+		SuggestedFix{Message: "Remove leading zeros", TextEdits: []TextEdit{{lit.Pos(), lit.End(), []byte(fixed)}}})
+}
+
+// hasLeadingZero returns true for legacy octal literals such as 0755, not 0, 0x1f or 0o17.
+func hasLeadingZero(lit string) bool {
+	return len(lit) > 1 && lit[0] == '0' && (lit[1] >= '0' && lit[1] <= '9' || lit[1] == '_')
+}
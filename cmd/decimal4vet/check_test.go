@@ -0,0 +1,167 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+// decimal4Src stands in for package decimal4 so the tests do not depend on GOPATH.
+const decimal4Src = `package decimal4
+
+type Decimal4 int64
+type Decimal6 int64
+
+func New(x float64) Decimal4 { return Decimal4(x * 10000) }
+func NewDecimal6(x float64) Decimal6 { return Decimal6(x * 1000000) }
+func MustFromInt(n int64) Decimal4 { return Decimal4(n * 10000) }
+func MustDecimal6FromInt(n int64) Decimal6 { return Decimal6(n * 1000000) }
+func (this Decimal4) Multiply(x Decimal4) Decimal4 { return this * x / 10000 }
+func (this Decimal4) Multiply6(x Decimal6) Decimal4 { return this * Decimal4(x) / 1000000 }
+func (this Decimal4) MultiplyInt(x int) Decimal4 { return this * Decimal4(x) }
+func (this Decimal4) DivideInt(x int) Decimal4 { return this / Decimal4(x) }
+func (this Decimal4) RoundToIncrement(inc Decimal4, mode int) Decimal4 { return this / inc * inc }
+`
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+// checkSrc type-checks src as package p and returns its diagnostics.
+func checkSrc(t *testing.T, src string) (*token.FileSet, []Diagnostic) {
+	fset := token.NewFileSet()
+	config := types.Config{Error: func(error) {}}
+	parse := func(name, src string) *ast.File {
+		file, err := parser.ParseFile(fset, name, src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	dec, err := config.Check(decimal4Path, fset, []*ast.File{parse("decimal4.go", decimal4Src)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Importer = importerFunc(func(path string) (*types.Package, error) { return dec, nil })
+	file := parse("p.go", src)
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	pkg, _ := config.Check("p", fset, []*ast.File{file}, info)
+	return fset, check(pkg, []*ast.File{file}, info)
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		body     string
+		expected []string // messages
+	}{
+		{"x = x.Multiply(5)", []string{"constant 5 converted to Decimal4 is 0.0005, use decimal4.MustFromInt(5) or decimal4.Decimal4(5)"}},
+		{"x = x.Multiply(-5)", []string{"constant -5 converted to Decimal4 is -0.0005, use decimal4.MustFromInt(-5) or decimal4.Decimal4(-5)"}},
+		{"x = x.Multiply6(5)", []string{"constant 5 converted to Decimal6 is 0.000005, use decimal4.MustDecimal6FromInt(5) or decimal4.Decimal6(5)"}},
+		{"x = x.Multiply(ten)", []string{"constant ten converted to Decimal4 is 0.0010, use decimal4.MustFromInt(ten) or decimal4.Decimal4(ten)"}},
+		{"x = 5", []string{"constant 5 converted to Decimal4 is 0.0005, use decimal4.MustFromInt(5) or decimal4.Decimal4(5)"}},
+		{"x += 5", []string{"constant 5 converted to Decimal4 is 0.0005, use decimal4.MustFromInt(5) or decimal4.Decimal4(5)"}},
+		{"x = x * 2", []string{"constant 2 in x * 2 scales the raw value, use x.MultiplyInt(2) or decimal4.Decimal4(2)"}},
+		{"x = 2 * (x + y)", []string{"constant 2 in 2 * (x + y) scales the raw value, use (x + y).MultiplyInt(2) or decimal4.Decimal4(2)"}},
+		{"x = x / 4", []string{"constant 4 in x / 4 scales the raw value, use x.DivideInt(4) (rounded) or decimal4.Decimal4(4)"}},
+		{"x = x % 3", []string{"constant 3 in x % 3 scales the raw value, use decimal4.Decimal4(3)"}},
+		{"x = 4 / x", []string{"constant 4 converted to Decimal4 is 0.0004, use decimal4.Decimal4(4) or decimal4.MustFromInt(4)"}},
+		{"x *= 2", []string{"constant 2 converted to Decimal4 is 0.0002, use decimal4.Decimal4(2) or decimal4.MustFromInt(2)"}},
+		{"x = x.RoundToIncrement(500, 0)", []string{"constant 500 converted to Decimal4 is 0.0500, use decimal4.Decimal4(500) or decimal4.MustFromInt(500)"}},
+		{"if x > 100 {}", []string{"constant 100 converted to Decimal4 is 0.0100, use decimal4.MustFromInt(100) or decimal4.Decimal4(100)"}},
+		{"var y decimal4.Decimal4 = 7; _ = y", []string{"constant 7 converted to Decimal4 is 0.0007, use decimal4.MustFromInt(7) or decimal4.Decimal4(7)"}},
+		{"_ = func() decimal4.Decimal4 { return 3 }", []string{"constant 3 converted to Decimal4 is 0.0003, use decimal4.MustFromInt(3) or decimal4.Decimal4(3)"}},
+		{"x = x + 123456789012345", []string{"constant 123456789012345 converted to Decimal4 is 12345678901.2345, use decimal4.MustFromInt(123456789012345) or decimal4.Decimal4(123456789012345)"}},
+		{"x = x + 1000000000000000", []string{"constant 1000000000000000 converted to Decimal4 is 100000000000.0000, use decimal4.Decimal4(1000000000000000)"}},
+		{"x = []decimal4.Decimal4{0031250}[0]", []string{"literal 0031250 has a leading zero and is octal, write 31250"}},
+		{"x = map[string]decimal4.Decimal4{\"a\": 0500}[\"a\"]", []string{"literal 0500 has a leading zero and is octal, write 500"}},
+
+		// not reported
+		{"x = x.Multiply(0)", nil},
+		{"x = x.Multiply(decimal4.Decimal4(5))", nil},
+		{"x = x.Multiply(decimal4.MustFromInt(5))", nil},
+		{"x = x.Multiply(decimal4.New(5))", nil},
+		{"x = x.Multiply(y)", nil},
+		{"x = x.Multiply(typed)", nil},
+		{"x = x * x", nil},
+		{"x = x / 2.5", nil},
+		{"n := 5; _ = n * 2", nil},
+		{"x = []decimal4.Decimal4{31250}[0]", nil},
+		{"_ = []int{0755}", nil},
+		{"_ = []decimal4.Decimal4{0, 0x1f, 0o17}", nil},
+	}
+	for _, test := range tests {
+		src := "package p\n\nimport \"github.com/txjmp/decimal4\"\n\nconst ten = 10\nconst typed = decimal4.Decimal4(10000)\n\n" +
+			"func f(x, y decimal4.Decimal4) {\n" + test.body + "\n_ = x\n}\n"
+		_, diagnostics := checkSrc(t, src)
+		var messages []string
+		for _, d := range diagnostics {
+			messages = append(messages, d.Message)
+		}
+		if strings.Join(messages, "\n") != strings.Join(test.expected, "\n") {
+			t.Errorf("%s: expected %q, got %q", test.body, test.expected, messages)
+		}
+	}
+}
+
+func TestCheckFix(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		{
+			"package p\n\nimport \"github.com/txjmp/decimal4\"\n\nfunc f(x decimal4.Decimal4) decimal4.Decimal4 {\n\treturn x.Multiply6(-5) + 2\n}\n",
+			"package p\n\nimport \"github.com/txjmp/decimal4\"\n\nfunc f(x decimal4.Decimal4) decimal4.Decimal4 {\n\treturn x.Multiply6(decimal4.MustDecimal6FromInt(-5)) + decimal4.MustFromInt(2)\n}\n",
+		},
+		{
+			"package p\n\nimport \"github.com/txjmp/decimal4\"\n\nvar y = decimal4.New(1) + 123456789012345 + 10000000000000000\n",
+			"package p\n\nimport \"github.com/txjmp/decimal4\"\n\nvar y = decimal4.New(1) + decimal4.MustFromInt(123456789012345) + decimal4.Decimal4(10000000000000000)\n",
+		},
+		{
+			"package p\n\nimport d4 \"github.com/txjmp/decimal4\"\n\nvar rates = []d4.Decimal4{0031250, 0500}\n\nfunc f(x d4.Decimal4) d4.Decimal4 {\n\treturn x.Multiply(5)\n}\n",
+			"package p\n\nimport d4 \"github.com/txjmp/decimal4\"\n\nvar rates = []d4.Decimal4{31250, 500}\n\nfunc f(x d4.Decimal4) d4.Decimal4 {\n\treturn x.Multiply(d4.MustFromInt(5))\n}\n",
+		},
+		{
+			// the value must not change: x * 2 doubles x, RoundToIncrement takes raw units
+			"package p\n\nimport \"github.com/txjmp/decimal4\"\n\nfunc f(x decimal4.Decimal4) {\n\ty := x * 2\n\tz := x / 4\n\tx *= 3\n\t_, _ = y, z.RoundToIncrement(500, 0)\n}\n",
+			"package p\n\nimport \"github.com/txjmp/decimal4\"\n\nfunc f(x decimal4.Decimal4) {\n\ty := x * decimal4.Decimal4(2)\n\tz := x / decimal4.Decimal4(4)\n\tx *= decimal4.Decimal4(3)\n\t_, _ = y, z.RoundToIncrement(decimal4.Decimal4(500), 0)\n}\n",
+		},
+	}
+	for _, test := range tests {
+		fset, diagnostics := checkSrc(t, test.src)
+		var edits []TextEdit
+		for _, d := range diagnostics {
+			edits = append(edits, d.SuggestedFixes[0].TextEdits...)
+		}
+		result := string(applyEdits(fset, []byte(test.src), edits))
+		if result != test.expected {
+			t.Errorf("expected\n%s\ngot\n%s", test.expected, result)
+		}
+	}
+}
+
+func TestHasLeadingZero(t *testing.T) {
+	tests := []struct {
+		lit      string
+		expected bool
+	}{
+		{"0", false},
+		{"10", false},
+		{"0x1f", false},
+		{"0o17", false},
+		{"0b101", false},
+		{"0755", true},
+		{"0_755", true},
+		{"09", true},
+	}
+	for _, test := range tests {
+		if result := hasLeadingZero(test.lit); result != test.expected {
+			t.Errorf("hasLeadingZero(%s) expected %v, got %v", test.lit, test.expected, result)
+		}
+	}
+}
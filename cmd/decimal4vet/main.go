@@ -0,0 +1,149 @@
+// Command decimal4vet reports untyped integer constants implicitly converted to
+// Decimal4 or Decimal6, such as x.Multiply(5) which multiplies by .0005,
+// and Decimal4 or Decimal6 literals with leading zeros, which Go reads as octal.
+//
+// Usage:
+//
+//	decimal4vet [-fix] [directory ...]
+//
+// Each directory (default ".") is checked as one package, including its test files.
+// Exit status is 1 if anything is reported, 2 if a package does not load, parse or type-check.
+// With -fix, the first suggested fix of each diagnostic is applied, for example
+// x.Multiply(5) becomes x.Multiply(decimal4.MustFromInt(5)). Fixes never change a value
+// written in raw units: x * 2 becomes x * decimal4.Decimal4(2), which still doubles x.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	fix := flag.Bool("fix", false, "apply the first suggested fix of each diagnostic")
+	flag.Parse()
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+	found := false
+	for _, dir := range dirs {
+		n, err := vetDir(dir, *fix)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "decimal4vet:", err)
+			os.Exit(2)
+		}
+		found = found || n > 0
+	}
+	if found && !*fix {
+		os.Exit(1)
+	}
+}
+
+// vetDir checks the package in dir, prints its diagnostics and returns how many were found.
+// Returns an error if the package does not parse or type-check, other than for octal literals
+// with the digits 8 or 9, which are reported as diagnostics.
+func vetDir(dir string, fix bool) (int, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return 0, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	var errs []error
+	for _, name := range append(bp.GoFiles, bp.TestGoFiles...) {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if list, ok := err.(scanner.ErrorList); ok {
+			for _, e := range list {
+				if !strings.Contains(e.Msg, "octal literal") {
+					errs = append(errs, e)
+				}
+			}
+		} else if err != nil {
+			return 0, err
+		}
+		files = append(files, file)
+	}
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	var typeErrs []types.Error
+	config := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(err error) { typeErrs = append(typeErrs, err.(types.Error)) }, // keep checking
+	}
+	pkg, _ := config.Check(bp.ImportPath, fset, files, info)
+
+	diagnostics := check(pkg, files, info)
+	octal := make(map[token.Pos]bool)
+	for _, d := range diagnostics {
+		fmt.Printf("%s: %s\n", fset.Position(d.Pos), d.Message)
+		octal[d.Pos] = true
+	}
+	for _, e := range typeErrs {
+		if !octal[e.Pos] {
+			errs = append(errs, e)
+		}
+	}
+	if len(errs) > 0 {
+		for _, e := range errs {
+			fmt.Fprintln(os.Stderr, e)
+		}
+		return len(diagnostics), fmt.Errorf("%s: %d errors, package not checked completely", dir, len(errs))
+	}
+	if fix {
+		return len(diagnostics), applyFixes(fset, diagnostics)
+	}
+	return len(diagnostics), nil
+}
+
+// applyFixes rewrites files with the first suggested fix of each diagnostic.
+func applyFixes(fset *token.FileSet, diagnostics []Diagnostic) error {
+	edits := make(map[string][]TextEdit)
+	for _, d := range diagnostics {
+		if len(d.SuggestedFixes) > 0 {
+			for _, edit := range d.SuggestedFixes[0].TextEdits {
+				name := fset.Position(edit.Pos).Filename
+				edits[name] = append(edits[name], edit)
+			}
+		}
+	}
+	for name, list := range edits {
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(name, applyEdits(fset, src, list), 0666); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyEdits returns src with edits applied. Overlapping edits after the first are skipped.
+func applyEdits(fset *token.FileSet, src []byte, edits []TextEdit) []byte {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Pos < edits[j].Pos })
+	var out []byte
+	last := 0
+	for _, edit := range edits {
+		start, end := fset.Position(edit.Pos).Offset, fset.Position(edit.End).Offset
+		if start < last {
+			continue
+		}
+		out = append(out, src[last:start]...)
+		out = append(out, edit.NewText...)
+		last = end
+	}
+	return append(out, src[last:]...)
+}
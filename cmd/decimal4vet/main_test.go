@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVetDir(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected int
+		err      bool
+	}{
+		{"package p\n\nvar x = 5\n", 0, false},
+		{"package p\n\nvar x = []int{0755}\n", 0, false},
+		{"package p\n\nvar x = []int{09}\n", 1, false}, // invalid octal is reported, not a load error
+		{"package p\n\nimport \"example.com/missing/decimal4\"\n\nvar x decimal4.Decimal4\n", 0, true},
+		{"package p\n\nvar x int = \"a\"\n", 0, true},
+	}
+	for _, test := range tests {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "p.go"), []byte(test.src), 0666); err != nil {
+			t.Fatal(err)
+		}
+		n, err := vetDir(dir, false)
+		if n != test.expected || (err != nil) != test.err {
+			t.Errorf("%q: expected %d diagnostics, error %v, got %d, %v", test.src, test.expected, test.err, n, err)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package decimal4
+
+import (
+	"log"
+	"math"
+	"sort"
+)
+
+// Cmp returns -1 if this < x, 0 if this == x, 1 if this > x.
+// Decimal4.Cmp can be passed to slices.SortFunc.
+func (this Decimal4) Cmp(x Decimal4) int {
+	return Compare(this, x)
+}
+
+// Sign returns -1, 0 or 1.
+func (this Decimal4) Sign() int {
+	return Compare(this, 0)
+}
+
+// IsZero returns true if this is 0.
+func (this Decimal4) IsZero() bool {
+	return this == 0
+}
+
+// IsNegative returns true if this is less than 0.
+func (this Decimal4) IsNegative() bool {
+	return this < 0
+}
+
+// Min returns the smallest of this and x.
+func (this Decimal4) Min(x ...Decimal4) Decimal4 {
+	for _, v := range x {
+		if v < this {
+			this = v
+		}
+	}
+	return this
+}
+
+// Max returns the largest of this and x.
+func (this Decimal4) Max(x ...Decimal4) Decimal4 {
+	for _, v := range x {
+		if v > this {
+			this = v
+		}
+	}
+	return this
+}
+
+// Clamp returns this limited to lo through hi. Panics if lo > hi.
+func (this Decimal4) Clamp(lo, hi Decimal4) Decimal4 {
+	if lo > hi {
+		log.Panic("Decimal4 Clamp invalid lo=", lo, " hi=", hi)
+	}
+	return this.Max(lo).Min(hi)
+}
+
+// Neg returns -this, or ErrOverflow if this is MinDecimal4.
+func (this Decimal4) Neg() (Decimal4, error) {
+	if this == math.MinInt64 {
+		return 0, ErrOverflow
+	}
+	return -this, nil
+}
+
+// Abs returns the absolute value of this, or ErrOverflow if this is MinDecimal4.
+func (this Decimal4) Abs() (Decimal4, error) {
+	if this < 0 {
+		return this.Neg()
+	}
+	return this, nil
+}
+
+// Within returns true if this and x differ by no more than tol, for example .005 to reconcile cents.
+// Unlike CloseTo, the tolerance is chosen by the caller.
+func (this Decimal4) Within(x, tol Decimal4) bool {
+	return within(int64(this), int64(x), int64(tol))
+}
+
+// Cmp returns -1 if this < x, 0 if this == x, 1 if this > x.
+func (this Decimal6) Cmp(x Decimal6) int {
+	return Compare(this, x)
+}
+
+// Sign returns -1, 0 or 1.
+func (this Decimal6) Sign() int {
+	return Compare(this, 0)
+}
+
+// IsZero returns true if this is 0.
+func (this Decimal6) IsZero() bool {
+	return this == 0
+}
+
+// IsNegative returns true if this is less than 0.
+func (this Decimal6) IsNegative() bool {
+	return this < 0
+}
+
+// Min returns the smallest of this and x.
+func (this Decimal6) Min(x ...Decimal6) Decimal6 {
+	for _, v := range x {
+		if v < this {
+			this = v
+		}
+	}
+	return this
+}
+
+// Max returns the largest of this and x.
+func (this Decimal6) Max(x ...Decimal6) Decimal6 {
+	for _, v := range x {
+		if v > this {
+			this = v
+		}
+	}
+	return this
+}
+
+// Clamp returns this limited to lo through hi. Panics if lo > hi.
+func (this Decimal6) Clamp(lo, hi Decimal6) Decimal6 {
+	if lo > hi {
+		log.Panic("Decimal6 Clamp invalid lo=", lo, " hi=", hi)
+	}
+	return this.Max(lo).Min(hi)
+}
+
+// Neg returns -this, or ErrOverflow if this is the smallest Decimal6.
+func (this Decimal6) Neg() (Decimal6, error) {
+	if this == math.MinInt64 {
+		return 0, ErrOverflow
+	}
+	return -this, nil
+}
+
+// Abs returns the absolute value of this, or ErrOverflow if this is the smallest Decimal6.
+func (this Decimal6) Abs() (Decimal6, error) {
+	if this < 0 {
+		return this.Neg()
+	}
+	return this, nil
+}
+
+// Within returns true if this and x differ by no more than tol.
+func (this Decimal6) Within(x, tol Decimal6) bool {
+	return within(int64(this), int64(x), int64(tol))
+}
+
+// within returns true if |a - b| <= tol, without overflow.
+func within(a, b, tol int64) bool {
+	if tol < 0 {
+		return false
+	}
+	if a < b {
+		a, b = b, a
+	}
+	return uint64(a)-uint64(b) <= uint64(tol)
+}
+
+// Compare returns -1 if a < b, 0 if a == b, 1 if a > b.
+// It can be passed to slices.SortFunc and slices.BinarySearchFunc for Decimal4, Decimal6 and Fixed slices.
+func Compare[T Decimal](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Decimal4Slice attaches the methods of sort.Interface to []Decimal4, sorting in increasing order.
+type Decimal4Slice []Decimal4
+
+func (this Decimal4Slice) Len() int           { return len(this) }
+func (this Decimal4Slice) Less(i, j int) bool { return this[i] < this[j] }
+func (this Decimal4Slice) Swap(i, j int)      { this[i], this[j] = this[j], this[i] }
+
+// Sort sorts this in increasing order.
+func (this Decimal4Slice) Sort() { sort.Sort(this) }
+
+// Decimal6Slice attaches the methods of sort.Interface to []Decimal6, sorting in increasing order.
+type Decimal6Slice []Decimal6
+
+func (this Decimal6Slice) Len() int           { return len(this) }
+func (this Decimal6Slice) Less(i, j int) bool { return this[i] < this[j] }
+func (this Decimal6Slice) Swap(i, j int)      { this[i], this[j] = this[j], this[i] }
+
+// Sort sorts this in increasing order.
+func (this Decimal6Slice) Sort() { sort.Sort(this) }
@@ -0,0 +1,122 @@
+package decimal4
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	a, b := New(1.5), New(-2)
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(a) != 0 {
+		t.Error("Cmp failed")
+	}
+	if a.Sign() != 1 || b.Sign() != -1 || Decimal4(0).Sign() != 0 {
+		t.Error("Sign failed")
+	}
+	if !Decimal4(0).IsZero() || a.IsZero() || !b.IsNegative() || a.IsNegative() {
+		t.Error("IsZero/IsNegative failed")
+	}
+	if a.Min() != a || a.Min(b, New(3)) != b || a.Max(b, New(3)) != New(3) {
+		t.Error("Min/Max failed")
+	}
+	type input struct {
+		x, clamped float64
+	}
+	for i, v := range []input{{-1, 0}, {0, 0}, {.5, .5}, {1, 1}, {2, 1}} {
+		if c := New(v.x).Clamp(0, New(1)); c != New(v.clamped) {
+			t.Errorf("data[%d]: Clamp should be %f, but is %s", i, v.clamped, c)
+		}
+	}
+	if x, err := b.Neg(); err != nil || x != New(2) {
+		t.Error("Neg should be 2, but is", x, err)
+	}
+	if x, err := b.Abs(); err != nil || x != New(2) {
+		t.Error("Abs should be 2, but is", x, err)
+	}
+	if _, err := MinDecimal4.Neg(); err != ErrOverflow {
+		t.Error("Neg expected ErrOverflow, got", err)
+	}
+	if _, err := MinDecimal4.Abs(); err != ErrOverflow {
+		t.Error("Abs expected ErrOverflow, got", err)
+	}
+	if x := Abs(MinDecimal4); x != MinDecimal4 {
+		t.Error("Abs(MinDecimal4) should be MinDecimal4, but is", x)
+	}
+	func() {
+		defer SetHandler(SetHandler(panicHandler{}))
+		if !panics(func() { a.Clamp(1, 0) }) {
+			t.Error("Clamp with lo > hi should panic")
+		}
+	}()
+}
+
+func TestWithin(t *testing.T) {
+	type input struct {
+		x, y, tol float64
+		within    bool
+	}
+	data := []input{
+		{1.005, 1.01, .005, true},
+		{1.005, 1.0101, .005, false},
+		{-1, 1, 2, true},
+		{-1, 1, 1.9999, false},
+		{1, 1, 0, true},
+		{1, 1, -1, false},
+	}
+	for i, v := range data {
+		if New(v.x).Within(New(v.y), New(v.tol)) != v.within {
+			t.Errorf("data[%d]: Within should be %t", i, v.within)
+		}
+	}
+	if MinDecimal4.Within(MaxDecimal4, MaxDecimal4) || !MinDecimal4.Within(-1, MaxDecimal4) {
+		t.Error("Within should not overflow")
+	}
+	if !NewDecimal6(.000001).Within(0, 1) || NewDecimal6(.000002).Within(0, 1) {
+		t.Error("Decimal6 Within failed")
+	}
+}
+
+func TestCompareDecimal6(t *testing.T) {
+	a, b := NewDecimal6(.000001), NewDecimal6(-1)
+	if a.Cmp(b) != 1 || a.Sign() != 1 || b.Sign() != -1 || Decimal6(0).Sign() != 0 || !Decimal6(0).IsZero() || !b.IsNegative() {
+		t.Error("Decimal6 Cmp/Sign failed")
+	}
+	if a.Min(b) != b || b.Max(a) != a || b.Clamp(0, a) != 0 || NewDecimal6(5).Clamp(0, a) != a {
+		t.Error("Decimal6 Min/Max/Clamp failed")
+	}
+	if x, _ := b.Abs(); x != NewDecimal6(1) {
+		t.Error("Decimal6 Abs should be 1, but is", x)
+	}
+	if _, err := Decimal6(math.MinInt64).Abs(); err != ErrOverflow {
+		t.Error("Decimal6 Abs expected ErrOverflow, got", err)
+	}
+}
+
+func TestSortSlices(t *testing.T) {
+	d4 := Decimal4Slice{New(3), New(-1), New(2), 0}
+	d4.Sort()
+	if !sort.IsSorted(d4) || d4[0] != New(-1) || d4[3] != New(3) {
+		t.Error("Decimal4Slice not sorted", d4)
+	}
+	d6 := Decimal6Slice{NewDecimal6(.3), NewDecimal6(-.1), NewDecimal6(.2)}
+	sort.Sort(sort.Reverse(d6))
+	if d6[0] != NewDecimal6(.3) || d6[2] != NewDecimal6(-.1) {
+		t.Error("Decimal6Slice not reverse sorted", d6)
+	}
+
+	values := []Decimal4{New(3), New(-1), New(2)}
+	slices.SortFunc(values, Compare)
+	if !slices.IsSortedFunc(values, Decimal4.Cmp) {
+		t.Error("slices.SortFunc with Compare failed", values)
+	}
+	if i, found := slices.BinarySearchFunc(values, New(2), Compare); !found || i != 1 {
+		t.Error("BinarySearchFunc should find 2 at 1, got", i, found)
+	}
+	fixed := []Decimal2{300, -100}
+	slices.SortFunc(fixed, Compare)
+	if fixed[0] != -100 {
+		t.Error("Fixed slice not sorted", fixed)
+	}
+}
@@ -0,0 +1,119 @@
+package decimal4
+
+import (
+	"errors"
+	"log"
+	"math"
+)
+
+// ErrFraction is returned by FromParts when the fraction does not fit its places or has the wrong sign.
+var ErrFraction = errors.New("decimal4: fraction out of range")
+
+// scaleUnits returns x * 10^(to-from), places 0 to 18.
+// Returns ErrInexact if non-zero digits would be dropped, ErrOverflow if the result does not fit.
+func scaleUnits(x int64, from, to int) (int64, error) {
+	if to < from {
+		unit := pow10[from-to]
+		if x%unit != 0 {
+			return 0, ErrInexact
+		}
+		return x / unit, nil
+	}
+	unit := pow10[to-from]
+	if x > math.MaxInt64/unit || x < math.MinInt64/unit {
+		return 0, ErrOverflow
+	}
+	return x * unit, nil
+}
+
+func validPlaces(places int) bool {
+	return places >= 0 && places <= 18
+}
+
+// FromInt returns n whole units, for example FromInt(5) is 5.0000 (stored as 50000).
+// Returns ErrOverflow if n is beyond about ±922 trillion.
+func FromInt(n int64) (Decimal4, error) {
+	x, err := scaleUnits(n, 0, 4)
+	return Decimal4(x), err
+}
+
+// MustFromInt is like FromInt for constants, for example x.Multiply(MustFromInt(5)).
+// Overflow is reported to the Handler (panic by default).
+func MustFromInt(n int64) Decimal4 {
+	x, err := FromInt(n)
+	if err != nil {
+		overflow("Decimal4 MustFromInt", n)
+	}
+	return x
+}
+
+// Decimal6FromInt returns n whole units as a Decimal6, for example 5.000000 (stored as 5000000).
+// Returns ErrOverflow if n is beyond about ±9.2 trillion.
+func Decimal6FromInt(n int64) (Decimal6, error) {
+	x, err := scaleUnits(n, 0, 6)
+	return Decimal6(x), err
+}
+
+// MustDecimal6FromInt is like Decimal6FromInt for constants.
+// Overflow is reported to the Handler (panic by default).
+func MustDecimal6FromInt(n int64) Decimal6 {
+	x, err := Decimal6FromInt(n)
+	if err != nil {
+		overflow("Decimal6 MustDecimal6FromInt", n)
+	}
+	return x
+}
+
+// FromMinor returns units with places (0-18) implied decimal places,
+// for example FromMinor(1234, 2) is 12.34 from cents.
+// Returns ErrInexact if units has non-zero digits after 4 places, ErrOverflow if it does not fit.
+// Panics if places is not 0-18.
+func FromMinor(units int64, places int) (Decimal4, error) {
+	if !validPlaces(places) {
+		log.Panic("Decimal4 FromMinor invalid places=", places)
+	}
+	x, err := scaleUnits(units, places, 4)
+	return Decimal4(x), err
+}
+
+// FromParts returns whole + frac / 10^fracPlaces, for example FromParts(-12, -34, 2) is -12.34,
+// the inverse of Parts. whole and frac must not have different signs, and |frac| < 10^fracPlaces,
+// otherwise FromParts returns ErrFraction. Returns ErrInexact if frac has non-zero digits after 4 places,
+// ErrOverflow if the result does not fit. Panics if fracPlaces is not 0-18.
+func FromParts(whole, frac int64, fracPlaces int) (Decimal4, error) {
+	if !validPlaces(fracPlaces) {
+		log.Panic("Decimal4 FromParts invalid fracPlaces=", fracPlaces)
+	}
+	if (whole < 0 && frac > 0) || (whole > 0 && frac < 0) || frac >= pow10[fracPlaces] || frac <= -pow10[fracPlaces] {
+		return 0, ErrFraction
+	}
+	w, err := scaleUnits(whole, 0, 4)
+	if err != nil {
+		return 0, err
+	}
+	f, err := scaleUnits(frac, fracPlaces, 4)
+	if err != nil {
+		return 0, err
+	}
+	x := w + f
+	if (f > 0 && x < w) || (f < 0 && x > w) {
+		return 0, ErrOverflow
+	}
+	return Decimal4(x), nil
+}
+
+// Minor returns this in units of places (0-18) decimal places, for example 12.34 is 1234 with places 2.
+// Returns ErrInexact if this has non-zero digits after places (round first, see RoundPlaces),
+// ErrOverflow if the result does not fit. Panics if places is not 0-18.
+func (this Decimal4) Minor(places int) (int64, error) {
+	if !validPlaces(places) {
+		log.Panic("Decimal4 Minor invalid places=", places)
+	}
+	return scaleUnits(int64(this), 4, places)
+}
+
+// Int64Units returns this in whole units, the inverse of FromInt, for example 5.0000 is 5.
+// Returns ErrInexact if this has a fractional part; IntPart truncates it instead.
+func (this Decimal4) Int64Units() (int64, error) {
+	return this.Minor(0)
+}
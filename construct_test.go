@@ -0,0 +1,109 @@
+package decimal4
+
+import "testing"
+
+func TestFromInt(t *testing.T) {
+	if x, err := FromInt(5); err != nil || x != New(5) {
+		t.Error("FromInt(5) should be 5, but is", x, err)
+	}
+	if x, err := FromInt(-922337203685477); err != nil || x != -9223372036854770000 {
+		t.Error("FromInt(-922337203685477) failed", x, err)
+	}
+	if _, err := FromInt(922337203685478); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+}
+
+func TestFromMinor(t *testing.T) {
+	type input struct {
+		units  int64
+		places int
+		want   float64
+		err    error
+	}
+	data := []input{
+		{1234, 2, 12.34, nil},
+		{-1234, 2, -12.34, nil},
+		{5, 0, 5, nil},
+		{12345678, 6, 12.3456, ErrInexact},
+		{12345600, 6, 12.3456, nil},
+		{1, 4, .0001, nil},
+		{1, 18, 0, ErrInexact},
+		{1000000000000000, 0, 0, ErrOverflow},
+	}
+	for i, v := range data {
+		x, err := FromMinor(v.units, v.places)
+		if err != v.err || err == nil && x != New(v.want) {
+			t.Errorf("data[%d]: should be %f %v, but is %s %v", i, v.want, v.err, x, err)
+		}
+	}
+}
+
+func TestFromParts(t *testing.T) {
+	type input struct {
+		whole, frac int64
+		places      int
+		want        float64
+		err         error
+	}
+	data := []input{
+		{12, 34, 2, 12.34, nil},
+		{12, 5, 2, 12.05, nil},
+		{-12, -34, 2, -12.34, nil},
+		{-12, 0, 2, -12, nil},
+		{0, -5, 1, -.5, nil},
+		{12, 3456, 4, 12.3456, nil},
+		{12, 345600, 6, 12.3456, nil},
+		{12, 345678, 6, 0, ErrInexact},
+		{12, 100, 2, 0, ErrFraction},
+		{-12, 34, 2, 0, ErrFraction},
+		{12, -34, 2, 0, ErrFraction},
+		{922337203685477, 9999, 4, 0, ErrOverflow},
+	}
+	for i, v := range data {
+		x, err := FromParts(v.whole, v.frac, v.places)
+		if err != v.err || err == nil && x != New(v.want) {
+			t.Errorf("data[%d]: should be %f %v, but is %s %v", i, v.want, v.err, x, err)
+		}
+	}
+	// inverse of Parts
+	for _, x := range []Decimal4{New(-12.3456), New(7.0001), 0, MaxDecimal4, MinDecimal4} {
+		whole, frac := x.Parts()
+		if y, err := FromParts(whole, frac, 4); err != nil || y != x {
+			t.Errorf("FromParts(%s.Parts()) is %s %v", x, y, err)
+		}
+	}
+}
+
+func TestMinor(t *testing.T) {
+	type input struct {
+		x      float64
+		places int
+		want   int64
+		err    error
+	}
+	data := []input{
+		{12.34, 2, 1234, nil},
+		{-12.34, 2, -1234, nil},
+		{12.345, 2, 0, ErrInexact},
+		{12.345, 3, 12345, nil},
+		{12, 0, 12, nil},
+		{1.5, 18, 1500000000000000000, nil},
+		{10, 18, 0, ErrOverflow},
+	}
+	for i, v := range data {
+		units, err := New(v.x).Minor(v.places)
+		if err != v.err || units != v.want {
+			t.Errorf("data[%d]: should be %d %v, but is %d %v", i, v.want, v.err, units, err)
+		}
+	}
+	for _, n := range []int64{0, 5, -5, 922337203685477} {
+		x, _ := FromInt(n)
+		if units, err := x.Int64Units(); err != nil || units != n {
+			t.Errorf("Int64Units should be %d, but is %d %v", n, units, err)
+		}
+	}
+	if _, err := New(12.34).Int64Units(); err != ErrInexact {
+		t.Error("Int64Units expected ErrInexact, got", err)
+	}
+}
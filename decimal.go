@@ -0,0 +1,19 @@
+package decimal4
+
+// Decimal is the constraint satisfied by Decimal4, Decimal6 and the Fixed types.
+// Values are int64 with Places() implied decimal places, so generic code can use
+// int64(x) as the raw value. See package generic for algorithms written over it.
+type Decimal interface {
+	~int64
+	Places() int
+}
+
+// Places returns 4, the number of implied decimal places.
+func (this Decimal4) Places() int {
+	return 4
+}
+
+// Places returns 6, the number of implied decimal places.
+func (this Decimal6) Places() int {
+	return 6
+}
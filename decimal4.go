@@ -28,7 +28,7 @@ func (this Decimal4) Multiply(x Decimal4) Decimal4 {
 		return 0
 	}
 	if a/x != this {
-		log.Panic("Decimal4 Multiply Overflow, this=", this, " x=", x)
+		overflow("Decimal4 Multiply", this, x)
 	}
 	if a > 0 {
 		a += 5000
@@ -45,7 +45,7 @@ func (this Decimal4) MultRound2(x Decimal4) Decimal4 {
 		return 0
 	}
 	if a/x != this {
-		log.Panic("Decimal4 MultRound2 Overflow, this=", this, " x=", x)
+		overflow("Decimal4 MultRound2", this, x)
 	}
 	if a > 0 {
 		a += 500000
@@ -68,7 +68,7 @@ func (this Decimal4) Multiply6(x Decimal6) Decimal4 {
 		return 0
 	}
 	if a/x != Decimal6(this) {
-		log.Panic("Decimal4 Multiply6 Overflow, this=", this, " x=", x)
+		overflow("Decimal4 Multiply6", this, x)
 	}
 	if a > 0 {
 		a += 500000
@@ -89,13 +89,16 @@ func (this Decimal4) MultiplyBig(x Decimal4) Decimal4 {
 		a = x
 		b = this
 	}
+	if a%100 != 0 {
+		inexact("Decimal4 MultiplyBig", this, x)
+	}
 	a = a / 100 // knock off last 2 decimal places of largest value
 	c = a * b
 	if c == 0 {
 		return 0
 	}
 	if c/a != b {
-		log.Panic("Decimal4 MultiplyBig Overflow, this=", this, " x=", x)
+		overflow("Decimal4 MultiplyBig", this, x)
 	}
 	if c > 0 {
 		c += 50
@@ -108,13 +111,16 @@ func (this Decimal4) MultiplyBig(x Decimal4) Decimal4 {
 // MultiplyBig6 allows for a larger maximum value than Multiply6 (before exceeding int64 max).
 // Last 2 decimal places are truncated on this value.
 func (this Decimal4) MultiplyBig6(x Decimal6) Decimal4 {
+	if this%100 != 0 {
+		inexact("Decimal4 MultiplyBig6", this, x)
+	}
 	a := Decimal6(this / 100) // knock off last 2 decimal places
 	b := a * x
 	if b == 0 {
 		return 0
 	}
 	if b/x != a {
-		log.Panic("Decimal4 MultiplyBig6 Overflow, this=", this, " x=", x)
+		overflow("Decimal4 MultiplyBig6", this, x)
 	}
 	if b > 0 {
 		b += 5000
@@ -132,19 +138,23 @@ func (this Decimal4) MultiplyInt(x int) Decimal4 {
 		return 0
 	}
 	if a/int64(x) != int64(this) {
-		log.Panic("Decimal4 MultiplyInt Overflow, this=", this, " x=", x)
+		overflow("Decimal4 MultiplyInt", this, x)
 	}
 	return Decimal4(a)
 }
 
 // Divide returns quotient of this / x rounded to 4 decimal places.
+// Panics if x is 0, including 0 / 0; Div(x, RoundHalfUp) is the checked form.
 func (this Decimal4) Divide(x Decimal4) Decimal4 {
+	if x == 0 {
+		log.Panic("Decimal4 Divide by zero, this=", this)
+	}
 	if this == 0 {
 		return 0
 	}
 	a := this * 100000 // shift over 5 places rather than 4, so result can be rounded
 	if a/100000 != this {
-		log.Panic("Decimal4 Divide Overflow, this=", this, " x=", x, " a=", a)
+		overflow("Decimal4 Divide", this, x, a)
 	}
 	b := a / x
 	if b > 0 {
@@ -156,30 +166,40 @@ func (this Decimal4) Divide(x Decimal4) Decimal4 {
 }
 
 // DivideBig returns quotient of this / x, 3 decimal places precision, no rounding.
+// Panics if x is 0.
 func (this Decimal4) DivideBig(x Decimal4) Decimal4 {
+	if x == 0 {
+		log.Panic("Decimal4 DivideBig by zero, this=", this)
+	}
 	if this == 0 {
 		return 0
 	}
 	a := this * 1000
 	if a/1000 != this {
-		log.Panic("Decimal4 DivideBig Overflow, this=", this, " x=", x, " a=", a)
+		overflow("Decimal4 DivideBig", this, x, a)
 	}
 	b := (a / x) * 10
 	if b/10 != (a / x) {
-		log.Panic("Decimal4 DivideBig Overflow, this=", this, " x=", x, " a=", a)
+		overflow("Decimal4 DivideBig", this, x, a)
+	}
+	if a%x != 0 {
+		inexact("Decimal4 DivideBig", this, x)
 	}
 	return b
 }
 
 // DivideInt returns quotient of this / x rounded to 4 decimal places.
-// Parameter x is type int.
+// Parameter x is type int. Panics if x is 0.
 func (this Decimal4) DivideInt(x int) Decimal4 {
+	if x == 0 {
+		log.Panic("Decimal4 DivideInt by zero, this=", this)
+	}
 	if this == 0 {
 		return 0
 	}
 	a := int64(this) * 10 // shift over 1 position so result can be rounded
 	if a/10 != int64(this) {
-		log.Panic("Decimal4 DivideInt Overflow, this=", this, " x=", x, " a=", a)
+		overflow("Decimal4 DivideInt", this, x, a)
 	}
 	b := a / int64(x)
 	if b > 0 {
@@ -273,7 +293,7 @@ func (this Decimal6) String() string {
 func (this Decimal4) Fmt(widthPrecision float64, currency ...string) string {
 	format := "%" + strconv.FormatFloat(widthPrecision, 'f', 1, 64) + "f"
 	fmtNum := fmt.Sprintf(format, float64(this)/10000)
-	if len(currency) == 0 && Abs(this) < 10000000 { // < 1 thousand
+	if len(currency) == 0 && absUint64(int64(this)) < 10000000 { // < 1 thousand, MinDecimal4 included
 		return fmtNum
 	}
 	if len(currency) == 0 {
@@ -294,11 +314,15 @@ func addCommas(in, currency string) string {
 	if dotNdx == -1 { // if no decimal point, assumed after last digit
 		dotNdx = len(inBytes)
 	}
-	// commaLocations are indexes where a comma should be inserted
-	commaLocations := []int{dotNdx - 4, dotNdx - 7, dotNdx - 10, dotNdx - 13}
+	// commaLocations are indexes where a comma should be inserted, -1 marks the end
+	commaLocations := []int{}
+	for ndx := dotNdx - 4; ndx >= 0; ndx -= 3 {
+		commaLocations = append(commaLocations, ndx)
+	}
+	commaLocations = append(commaLocations, -1)
 	commaNdx := 0 // used in loop below, indicates which commaLocation to use in comparison
 
-	outBytes := make([]byte, 50)
+	outBytes := make([]byte, len(inBytes)*2)
 
 	// load outBytes from inBytes, beginning with last byte, adding commas at commaLocations
 	outNdx := len(outBytes)
@@ -317,7 +341,7 @@ func addCommas(in, currency string) string {
 		}
 		outBytes[outNdx] = inBytes[i]
 	}
-	result := make([]byte, 0, 50)
+	result := make([]byte, 0, len(outBytes)+len(currency))
 	if currency == "" {
 		if spaceCount > 0 {
 			result = append(result, inBytes[0:spaceCount]...) // add leading spaces
@@ -354,6 +378,7 @@ func NewDecimal6(x float64) Decimal6 {
 	return 0
 }
 
+// Abs returns the absolute value of x. Abs(MinDecimal4) is MinDecimal4, see Decimal4.Abs for a checked version.
 func Abs(x Decimal4) Decimal4 {
 	if x < 0 {
 		return -x
@@ -411,6 +411,7 @@ func TestFmt(t *testing.T) {
 		{9123456789551292, 20.3, "", " 912,345,678,955.129"},
 		{12345600, 10.2, Dollar, " $1,234.56"},
 		{12345600, .3, "", "1,234.560"},
+		{MinDecimal4, .2, "", "-922,337,203,685,477.62"},
 	}
 	for _, v := range data {
 		result := v.val.Fmt(v.widthPrecision, v.currency)
@@ -418,6 +419,11 @@ func TestFmt(t *testing.T) {
 			t.Errorf("expected:%s   got:%s", v.output, result)
 		}
 	}
+	for _, x := range []Decimal4{MinDecimal4, MinDecimal4 + 1} {
+		if result := x.Fmt(.2); result != "-922,337,203,685,477.62" {
+			t.Errorf("expected:-922,337,203,685,477.62   got:%s", result)
+		}
+	}
 }
 
 func TestRound(t *testing.T) {
@@ -0,0 +1,216 @@
+package decimal4
+
+import "errors"
+
+// ErrDepreciationInput is returned when an Asset cannot be depreciated,
+// for example Life <= 0, Salvage > Cost, or StartMonth out of range.
+var ErrDepreciationInput = errors.New("decimal4: invalid depreciation input")
+
+// Convention determines how much of the first year an asset is depreciated.
+type Convention int
+
+const (
+	FullYear Convention = iota // full year's depreciation in year placed in service
+	HalfYear                   // half year in first year, remaining half after last full year
+	MidMonth                   // placed in service mid-month of StartMonth
+)
+
+// Asset describes a fixed asset to be depreciated.
+type Asset struct {
+	Cost       Decimal4
+	Salvage    Decimal4
+	Life       int        // useful life in years
+	Convention Convention // FullYear, HalfYear, MidMonth
+	StartMonth int        // 1-12, month placed in service, used by MidMonth
+}
+
+// Period is one line of a depreciation schedule.
+type Period struct {
+	Year         int      // 1 = year placed in service
+	Depreciation Decimal4 // depreciation for this period
+	Accumulated  Decimal4 // total depreciation through this period
+	BookValue    Decimal4 // Cost - Accumulated
+}
+
+// Time is measured in 24ths of a year, so half years and half months are exact.
+const periodsPerYear = 24
+
+// firstPortion returns the part of the first year depreciated, in 24ths.
+func (this Asset) firstPortion() int64 {
+	switch this.Convention {
+	case HalfYear:
+		return periodsPerYear / 2
+	case MidMonth:
+		return int64(25 - 2*this.StartMonth) // 12 - StartMonth + .5 months
+	}
+	return periodsPerYear
+}
+
+func (this Asset) validate() error {
+	if this.Life <= 0 || this.Cost < 0 || this.Salvage < 0 || this.Salvage > this.Cost {
+		return ErrDepreciationInput
+	}
+	switch this.Convention {
+	case FullYear, HalfYear:
+	case MidMonth:
+		if this.StartMonth < 1 || this.StartMonth > 12 {
+			return ErrDepreciationInput
+		}
+	default:
+		return ErrDepreciationInput
+	}
+	return nil
+}
+
+// elapsed returns asset time (in 24ths) at the end of each schedule year.
+// A partial first year adds one year to the schedule for the remainder.
+func (this Asset) elapsed() []int64 {
+	first := this.firstPortion()
+	life := int64(this.Life) * periodsPerYear
+	years := this.Life
+	if first < periodsPerYear {
+		years++
+	}
+	out := make([]int64, years)
+	for i := range out {
+		t := first + int64(i)*periodsPerYear
+		if t > life {
+			t = life
+		}
+		out[i] = t
+	}
+	return out
+}
+
+// schedule builds Periods from accumulated depreciation at the end of each year.
+func (this Asset) schedule(accumulated []Decimal4) []Period {
+	periods := make([]Period, len(accumulated))
+	var prior Decimal4
+	for i, acc := range accumulated {
+		periods[i] = Period{
+			Year:         i + 1,
+			Depreciation: acc - prior,
+			Accumulated:  acc,
+			BookValue:    this.Cost - acc,
+		}
+		prior = acc
+	}
+	return periods
+}
+
+// StraightLine returns a straight-line schedule.
+// Each year's accumulated depreciation is rounded from the exact value,
+// so the schedule always ends at Cost - Salvage.
+func StraightLine(a Asset) ([]Period, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	depreciable := int64(a.Cost - a.Salvage)
+	life := int64(a.Life) * periodsPerYear
+	elapsed := a.elapsed()
+	accumulated := make([]Decimal4, len(elapsed))
+	for i, t := range elapsed {
+		acc, _ := mulDiv(depreciable, t, life, RoundHalfUp) // result <= depreciable, cannot overflow
+		accumulated[i] = Decimal4(acc)
+	}
+	return a.schedule(accumulated), nil
+}
+
+// SumOfYearsDigits returns a sum-of-years'-digits schedule.
+// Partial years take the matching share of each asset year's depreciation.
+func SumOfYearsDigits(a Asset) ([]Period, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	depreciable := int64(a.Cost - a.Salvage)
+	life := int64(a.Life)
+	syd := life * (life + 1) / 2
+	elapsed := a.elapsed()
+	accumulated := make([]Decimal4, len(elapsed))
+	for i, t := range elapsed {
+		// digits used through asset time t, in 24ths
+		whole, part := t/periodsPerYear, t%periodsPerYear
+		used := periodsPerYear*(whole*life-whole*(whole-1)/2) + part*(life-whole)
+		acc, _ := mulDiv(depreciable, used, syd*periodsPerYear, RoundHalfUp)
+		accumulated[i] = Decimal4(acc)
+	}
+	return a.schedule(accumulated), nil
+}
+
+// DecliningBalance returns a declining balance schedule at factor / Life per year,
+// for example factor 2 (Decimal4 20000) is double-declining balance.
+// If switchToSL is true, each year uses straight-line over the remaining life
+// when that is greater. Book value never goes below Salvage and the last year
+// takes whatever remains, so the schedule always ends at Cost - Salvage.
+func DecliningBalance(a Asset, factor Decimal4, switchToSL bool) ([]Period, error) {
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+	if factor <= 0 {
+		return nil, ErrDepreciationInput
+	}
+	depreciable := a.Cost - a.Salvage
+	life := int64(a.Life) * periodsPerYear
+	elapsed := a.elapsed()
+	accumulated := make([]Decimal4, len(elapsed))
+	var acc Decimal4
+	var prior int64
+	for i, t := range elapsed {
+		remaining := depreciable - acc
+		if i == len(elapsed)-1 {
+			acc += remaining
+			accumulated[i] = acc
+			break
+		}
+		portion := t - prior
+		book := int64(a.Cost - acc)
+		dep, ok := mulDiv(book, int64(factor)*portion, life*10000, RoundHalfUp)
+		if !ok {
+			return nil, ErrDepreciationInput
+		}
+		if switchToSL {
+			sl, _ := mulDiv(int64(remaining), portion, life-prior, RoundHalfUp)
+			if sl > dep {
+				dep = sl
+			}
+		}
+		if Decimal4(dep) > remaining {
+			dep = int64(remaining)
+		}
+		acc += Decimal4(dep)
+		accumulated[i] = acc
+		prior = t
+	}
+	return a.schedule(accumulated), nil
+}
+
+// DoubleDeclining returns a double-declining balance schedule
+// that switches to straight-line when straight-line is greater.
+func DoubleDeclining(a Asset) ([]Period, error) {
+	return DecliningBalance(a, 20000, true)
+}
+
+// UnitsOfProduction returns a schedule based on units produced each period.
+// Accumulated depreciation is (Cost - Salvage) * units to date / totalUnits,
+// capped at Cost - Salvage once units to date reach totalUnits.
+// Asset Life, Convention and StartMonth are not used.
+func UnitsOfProduction(a Asset, totalUnits Decimal4, units []Decimal4) ([]Period, error) {
+	if a.Cost < 0 || a.Salvage < 0 || a.Salvage > a.Cost || totalUnits <= 0 {
+		return nil, ErrDepreciationInput
+	}
+	depreciable := int64(a.Cost - a.Salvage)
+	accumulated := make([]Decimal4, len(units))
+	var used Decimal4
+	for i, u := range units {
+		if u < 0 {
+			return nil, ErrDepreciationInput
+		}
+		used += u
+		if used >= totalUnits || used < 0 { // used < 0 if sum overflowed
+			used = totalUnits
+		}
+		acc, _ := mulDiv(depreciable, int64(used), int64(totalUnits), RoundHalfUp)
+		accumulated[i] = Decimal4(acc)
+	}
+	return a.schedule(accumulated), nil
+}
@@ -0,0 +1,85 @@
+package decimal4
+
+import "testing"
+
+func checkSchedule(t *testing.T, name string, periods []Period, expected []float64) {
+	if len(periods) != len(expected) {
+		t.Errorf("%s: expected %d periods, got %d", name, len(expected), len(periods))
+		return
+	}
+	for i, v := range expected {
+		if periods[i].Depreciation != New(v) {
+			t.Errorf("%s: year %d should be %f, but is %s", name, i+1, v, periods[i].Depreciation)
+		}
+	}
+}
+
+func TestDepreciation(t *testing.T) {
+	asset := Asset{Cost: New(10000), Salvage: New(1000), Life: 5}
+
+	periods, _ := StraightLine(asset)
+	checkSchedule(t, "StraightLine", periods, []float64{1800, 1800, 1800, 1800, 1800})
+
+	periods, _ = SumOfYearsDigits(asset)
+	checkSchedule(t, "SumOfYearsDigits", periods, []float64{3000, 2400, 1800, 1200, 600})
+
+	periods, _ = DoubleDeclining(asset)
+	checkSchedule(t, "DoubleDeclining", periods, []float64{4000, 2400, 1440, 864, 296})
+
+	periods, _ = DecliningBalance(Asset{Cost: New(1000), Salvage: New(100), Life: 5}, New(1.5), false)
+	checkSchedule(t, "DecliningBalance", periods, []float64{300, 210, 147, 102.9, 140.1})
+
+	periods, _ = DoubleDeclining(Asset{Cost: New(10000), Life: 5})
+	checkSchedule(t, "DoubleDeclining switch", periods, []float64{4000, 2400, 1440, 1080, 1080})
+
+	asset.Convention = HalfYear
+	periods, _ = StraightLine(asset)
+	checkSchedule(t, "StraightLine HalfYear", periods, []float64{900, 1800, 1800, 1800, 1800, 900})
+
+	periods, _ = SumOfYearsDigits(asset)
+	checkSchedule(t, "SumOfYearsDigits HalfYear", periods, []float64{1500, 2700, 2100, 1500, 900, 300})
+
+	periods, _ = UnitsOfProduction(asset, New(100), []Decimal4{New(30), New(50), New(40)})
+	checkSchedule(t, "UnitsOfProduction", periods, []float64{2700, 4500, 1800})
+}
+
+func TestDepreciationTiesOut(t *testing.T) {
+	type method func(Asset) ([]Period, error)
+	declining := func(a Asset) ([]Period, error) { return DecliningBalance(a, New(1.75), false) }
+	methods := []method{StraightLine, SumOfYearsDigits, DoubleDeclining, declining}
+	for _, life := range []int{1, 3, 7, 39} {
+		for month := 1; month <= 12; month++ {
+			asset := Asset{Cost: New(12345.6789), Salvage: New(333.33), Life: life, Convention: MidMonth, StartMonth: month}
+			for m, fn := range methods {
+				periods, err := fn(asset)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var total Decimal4
+				for _, p := range periods {
+					if p.Depreciation < 0 {
+						t.Errorf("method %d life %d month %d: negative depreciation %s", m, life, month, p.Depreciation)
+					}
+					total += p.Depreciation
+				}
+				last := periods[len(periods)-1]
+				if total != asset.Cost-asset.Salvage || last.BookValue != asset.Salvage {
+					t.Errorf("method %d life %d month %d: total %s, book value %s", m, life, month, total, last.BookValue)
+				}
+			}
+		}
+	}
+}
+
+func TestDepreciationInput(t *testing.T) {
+	data := []Asset{
+		{Cost: New(100), Life: 0},
+		{Cost: New(100), Salvage: New(101), Life: 5},
+		{Cost: New(100), Life: 5, Convention: MidMonth, StartMonth: 13},
+	}
+	for i, v := range data {
+		if _, err := StraightLine(v); err != ErrDepreciationInput {
+			t.Errorf("data[%d]: expected ErrDepreciationInput, got %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package decimal4
+
+import "log"
+
+// numDigits returns the number of decimal digits in u, 0 for 0.
+func numDigits(u uint64) int {
+	n := 0
+	for ; u > 0; u /= 10 {
+		n++
+	}
+	return n
+}
+
+// roundSig rounds x to n significant digits using mode.
+func roundSig(x int64, n int, mode RoundingMode) (int64, bool) {
+	drop := numDigits(absUint64(x)) - n
+	if drop <= 0 {
+		return x, true
+	}
+	return roundToUnit(x, pow10[drop], mode)
+}
+
+// magnitude returns the power of 10 of the most significant digit of x with places implied decimals.
+func magnitude(x int64, places int) int {
+	if x == 0 {
+		return 0
+	}
+	return numDigits(absUint64(x)) - 1 - places
+}
+
+// integerDigits returns the number of digits in the integer part of x with places implied decimals.
+func integerDigits(x int64, places int) int {
+	n := numDigits(absUint64(x)) - places
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// RoundSig returns this rounded to n significant digits using mode.
+// For example 1234.5678 -> 1235.0000 (n=4), .012345 -> .0123 (n=3).
+func (this Decimal4) RoundSig(n int, mode RoundingMode) Decimal4 {
+	if n <= 0 {
+		log.Panic("Decimal4 RoundSig invalid n=", n)
+	}
+	a, ok := roundSig(int64(this), n, mode)
+	if !ok {
+		overflow("Decimal4 RoundSig", this, n)
+	}
+	return Decimal4(a)
+}
+
+// Digits returns the number of digits in the integer part of this, 0 if Abs(this) < 1.
+func (this Decimal4) Digits() int {
+	return integerDigits(int64(this), 4)
+}
+
+// Magnitude returns the power of 10 of the most significant digit of this,
+// for example 123.4 -> 2, .05 -> -2. Magnitude of 0 is 0.
+func (this Decimal4) Magnitude() int {
+	return magnitude(int64(this), 4)
+}
+
+// IntPart returns the integer part of this, truncated toward zero.
+func (this Decimal4) IntPart() int64 {
+	return int64(this) / 10000
+}
+
+// FracPart returns the fractional part of this, with the same sign as this.
+func (this Decimal4) FracPart() Decimal4 {
+	return this % 10000
+}
+
+// Parts returns the integer part and the fractional part in 1/10000 units,
+// both with the same sign as this. For example -12.3456 -> -12, -3456.
+func (this Decimal4) Parts() (int64, int64) {
+	return int64(this) / 10000, int64(this) % 10000
+}
+
+// RoundSig returns this rounded to n significant digits using mode.
+// For example 1.2345678 -> 1.234570 (n=6).
+func (this Decimal6) RoundSig(n int, mode RoundingMode) Decimal6 {
+	if n <= 0 {
+		log.Panic("Decimal6 RoundSig invalid n=", n)
+	}
+	a, ok := roundSig(int64(this), n, mode)
+	if !ok {
+		overflow("Decimal6 RoundSig", this, n)
+	}
+	return Decimal6(a)
+}
+
+// Digits returns the number of digits in the integer part of this, 0 if abs(this) < 1.
+func (this Decimal6) Digits() int {
+	return integerDigits(int64(this), 6)
+}
+
+// Magnitude returns the power of 10 of the most significant digit of this.
+// Magnitude of 0 is 0.
+func (this Decimal6) Magnitude() int {
+	return magnitude(int64(this), 6)
+}
+
+// IntPart returns the integer part of this, truncated toward zero.
+func (this Decimal6) IntPart() int64 {
+	return int64(this) / 1000000
+}
+
+// FracPart returns the fractional part of this, with the same sign as this.
+func (this Decimal6) FracPart() Decimal6 {
+	return this % 1000000
+}
+
+// Parts returns the integer part and the fractional part in 1/1000000 units,
+// both with the same sign as this.
+func (this Decimal6) Parts() (int64, int64) {
+	return int64(this) / 1000000, int64(this) % 1000000
+}
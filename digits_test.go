@@ -0,0 +1,79 @@
+package decimal4
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRoundSig(t *testing.T) {
+	type input struct {
+		val    Decimal4
+		n      int
+		mode   RoundingMode
+		result Decimal4
+	}
+	data := []input{
+		{0, 3, RoundHalfUp, 0},
+		{12345678, 4, RoundHalfUp, 12350000},   // 1234.5678 -> 1235
+		{-12345678, 4, RoundHalfUp, -12350000}, // -1234.5678 -> -1235
+		{12345678, 4, RoundDown, 12340000},     // 1234.5678 -> 1234
+		{123, 2, RoundHalfUp, 120},             // .0123 -> .012
+		{125, 2, RoundHalfEven, 120},           // .0125 -> .012
+		{99996, 4, RoundHalfUp, 100000},        // 9.9996 -> 10.00
+		{12345, 9, RoundHalfUp, 12345},         // fewer digits than n
+		{math.MaxInt64, 1, RoundDown, 9000000000000000000},
+		{math.MinInt64, 3, RoundHalfUp, -9220000000000000000},
+		{math.MaxInt64, 19, RoundUp, math.MaxInt64},
+	}
+	for i, v := range data {
+		result := v.val.RoundSig(v.n, v.mode)
+		if result != v.result {
+			t.Errorf("data[%d]: result should be %d, but is %d", i, v.result, result)
+		}
+	}
+	if rate := Decimal6(1955830).RoundSig(6, RoundHalfUp); rate != 1955830 {
+		t.Errorf("Decimal6 RoundSig should be 1.955830, but is %s", rate)
+	}
+	if rate := Decimal6(40339945).RoundSig(6, RoundHalfUp); rate != 40339900 {
+		t.Errorf("Decimal6 RoundSig should be 40.3399, but is %s", rate)
+	}
+	if rate := Decimal6(-1234567).RoundSig(3, RoundFloor); rate != -1240000 {
+		t.Errorf("Decimal6 RoundSig should be -1.24, but is %s", rate)
+	}
+}
+
+func TestDigitsParts(t *testing.T) {
+	type input struct {
+		val       Decimal4
+		digits    int
+		magnitude int
+		intPart   int64
+		fracPart  int64
+	}
+	data := []input{
+		{0, 0, 0, 0, 0},
+		{5000, 0, -1, 0, 5000},
+		{500, 0, -2, 0, 500},
+		{10000, 1, 0, 1, 0},
+		{1234000, 3, 2, 123, 4000},
+		{-123456, 2, 1, -12, -3456},
+		{math.MaxInt64, 15, 14, 922337203685477, 5807},
+		{math.MinInt64, 15, 14, -922337203685477, -5808},
+	}
+	for i, v := range data {
+		intPart, fracPart := v.val.Parts()
+		if v.val.Digits() != v.digits || v.val.Magnitude() != v.magnitude ||
+			v.val.IntPart() != v.intPart || v.val.FracPart() != Decimal4(v.fracPart) ||
+			intPart != v.intPart || fracPart != v.fracPart {
+			t.Errorf("data[%d]: %d got digits %d, magnitude %d, parts %d %d",
+				i, v.val, v.val.Digits(), v.val.Magnitude(), intPart, fracPart)
+		}
+	}
+	d6 := Decimal6(math.MinInt64)
+	if d6.Digits() != 13 || d6.Magnitude() != 12 || d6.IntPart() != -9223372036854 || d6.FracPart() != -775808 {
+		t.Errorf("Decimal6 MinInt64 got digits %d, magnitude %d, parts %d %d", d6.Digits(), d6.Magnitude(), d6.IntPart(), d6.FracPart())
+	}
+	if d6 = Decimal6(-2500); d6.Digits() != 0 || d6.Magnitude() != -3 {
+		t.Errorf("Decimal6 -.0025 got digits %d, magnitude %d", d6.Digits(), d6.Magnitude())
+	}
+}
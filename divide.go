@@ -0,0 +1,18 @@
+package decimal4
+
+import "math"
+
+// DivideBigChecked returns quotient of this / x truncated to 3 decimal places, like DivideBig,
+// with a 128-bit intermediate so any result that fits is returned.
+// The checked forms of Divide and DivideInt are Div(x, RoundHalfUp) and DivInt(x, RoundHalfUp).
+// Returns ErrDivisionByZero if x is 0 (including 0 / 0), ErrOverflow if the result does not fit.
+func (this Decimal4) DivideBigChecked(x Decimal4) (Decimal4, error) {
+	if x == 0 {
+		return 0, ErrDivisionByZero
+	}
+	a, ok := mulDiv(int64(this), 1000, int64(x), RoundDown)
+	if !ok || a > math.MaxInt64/10 || a < math.MinInt64/10 {
+		return 0, ErrOverflow
+	}
+	return Decimal4(a * 10), nil
+}
@@ -0,0 +1,161 @@
+package decimal4
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestDivideChecked checks Div and DivInt with RoundHalfUp as the checked forms of Divide and DivideInt.
+func TestDivideChecked(t *testing.T) {
+	type input struct {
+		x, y, q float64
+	}
+	data := []input{
+		{10, 3, 3.3333},
+		{-10, 3, -3.3333},
+		{2, 3, .6667},
+		{0, 3, 0},
+		{900000000000, .5, 1800000000000}, // beyond the range of Divide
+	}
+	for i, v := range data {
+		q, err := New(v.x).Div(New(v.y), RoundHalfUp)
+		if err != nil || q != New(v.q) {
+			t.Errorf("data[%d]: should be %f, but is %s %v", i, v.q, q, err)
+		}
+	}
+	if q, _ := New(10).DivInt(3, RoundHalfUp); q != New(3.3333) || q != New(10).DivideInt(3) {
+		t.Error("DivInt should be 3.3333, but is", q)
+	}
+	if q, _ := New(-2).Div(New(3), RoundHalfUp); q != New(-2).Divide(New(3)) {
+		t.Error("Div should equal Divide, but is", q)
+	}
+	if _, err := Decimal4(1<<62).Div(New(.5), RoundHalfUp); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+	for _, v := range []input{{10, 3, 3.333}, {-2, 3, -.666}, {9000000000000, .5, 18000000000000}} {
+		if q, err := New(v.x).DivideBigChecked(New(v.y)); err != nil || q != New(v.q) {
+			t.Errorf("DivideBigChecked(%f, %f) should be %f, but is %s %v", v.x, v.y, v.q, q, err)
+		}
+	}
+	if q, err := New(10).DivideBigChecked(New(3)); q != New(10).DivideBig(New(3)) || err != nil {
+		t.Error("DivideBigChecked should equal DivideBig, but is", q, err)
+	}
+	if _, err := MaxDecimal4.DivideBigChecked(New(.5)); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+}
+
+// TestDivisionByZero checks every division path, with this 0 and non-zero:
+// panicking methods panic with a "by zero" message, checked functions return ErrDivisionByZero.
+func TestDivisionByZero(t *testing.T) {
+	panics := map[string]func(this Decimal4){
+		"Decimal4.Divide":       func(this Decimal4) { this.Divide(0) },
+		"Decimal4.DivideBig":    func(this Decimal4) { this.DivideBig(0) },
+		"Decimal4.DivideInt":    func(this Decimal4) { this.DivideInt(0) },
+		"Wide.Divide":           func(this Decimal4) { NewWide(this).Divide(0) },
+		"Wide.DivideInt":        func(this Decimal4) { NewWide(this).DivideInt(0) },
+		"BigDecimal4.Divide":    func(this Decimal4) { NewBig(this).Divide(BigDecimal4{}) },
+		"BigDecimal4.DivideInt": func(this Decimal4) { NewBig(this).DivideInt(0) },
+		"ExtractTax":            func(this Decimal4) { ExtractTax(this, NewDecimal6(-1)) },
+		"TaxSet.Extract": func(this Decimal4) {
+			TaxSet{Taxes: []Tax{{Rate: NewDecimal6(-1)}}}.Extract(this)
+		},
+	}
+	checked := map[string]func(this Decimal4) error{
+		"Decimal4.DivideBigChecked": func(this Decimal4) error {
+			_, err := this.DivideBigChecked(0)
+			return err
+		},
+		"Decimal4.Div": func(this Decimal4) error {
+			_, err := this.Div(0, RoundHalfUp)
+			return err
+		},
+		"Decimal4.DivInt": func(this Decimal4) error {
+			_, err := this.DivInt(0, RoundHalfUp)
+			return err
+		},
+		"Decimal6.Div": func(this Decimal4) error {
+			_, err := Decimal6(this).Div(0, RoundHalfUp)
+			return err
+		},
+		"Decimal4.QuoRem": func(this Decimal4) error {
+			_, _, err := this.QuoRem(0)
+			return err
+		},
+		"Decimal4.DivMod": func(this Decimal4) error {
+			_, _, err := this.DivMod(0, 2, RoundHalfUp)
+			return err
+		},
+		"Decimal4.Mod": func(this Decimal4) error {
+			_, err := this.Mod(0)
+			return err
+		},
+		"Decimal4.Rem": func(this Decimal4) error {
+			_, err := this.Rem(0)
+			return err
+		},
+		"Decimal4.PowInt": func(this Decimal4) error {
+			_, err := Decimal4(0).PowInt(-1, RoundHalfUp)
+			return err
+		},
+		"Decimal6.Pow": func(this Decimal4) error {
+			_, err := Decimal6(0).Pow(NewDecimal6(-.5), RoundHalfUp)
+			return err
+		},
+		"Decimal6.PowInt": func(this Decimal4) error {
+			_, err := Decimal6(0).PowInt(-2, RoundHalfUp)
+			return err
+		},
+		"RoundingMode.MulDiv": func(this Decimal4) error {
+			_, err := RoundHalfUp.MulDiv(int64(this), 10000, 0)
+			return err
+		},
+		"Fixed.Div": func(this Decimal4) error {
+			_, err := Fixed[Scale4](this).Div(0, RoundHalfUp)
+			return err
+		},
+		"Fixed.DivInt": func(this Decimal4) error {
+			_, err := Fixed[Scale4](this).DivInt(0, RoundHalfUp)
+			return err
+		},
+		"Div by Decimal6": func(this Decimal4) error {
+			_, err := Div(Fixed[Scale4](this), Fixed[Scale6](Decimal6(0)), RoundHalfUp)
+			return err
+		},
+		"Decimal6 Div": func(this Decimal4) error {
+			_, err := Fixed[Scale6](Decimal6(this)).Div(Fixed[Scale6](Decimal6(0)), RoundHalfUp)
+			return err
+		},
+		"TokenAmount.DivInt": func(this Decimal4) error {
+			token, _ := TokenFromDecimal4(this, 18, RoundHalfUp)
+			_, err := token.DivInt(0, RoundHalfUp)
+			return err
+		},
+		"TokenFromFiat": func(this Decimal4) error {
+			_, err := TokenFromFiat(this, Decimal6(0), 18, RoundHalfUp)
+			return err
+		},
+		"WeightedMean": func(this Decimal4) error {
+			_, err := WeightedMean([]Decimal4{this}, []Decimal6{0}, RoundHalfUp)
+			return err
+		},
+	}
+	for _, this := range []Decimal4{0, New(5), New(-5)} {
+		for name, f := range panics {
+			func() {
+				defer func() {
+					if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "by zero") {
+						t.Errorf("%s: %s / 0 should panic by zero, got %v", name, this, r)
+					}
+				}()
+				f(this)
+			}()
+		}
+		for name, f := range checked {
+			if err := f(this); err != ErrDivisionByZero {
+				t.Errorf("%s: %s / 0 should return ErrDivisionByZero, got %v", name, this, err)
+			}
+		}
+	}
+}
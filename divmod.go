@@ -0,0 +1,80 @@
+package decimal4
+
+import (
+	"errors"
+	"log"
+	"math"
+	"math/big"
+)
+
+// QuoRem returns the integer quotient of this / x, truncated toward zero, and the remainder
+// this - q*x, which has the sign of this. For example 10.5 / 3 is 3 remainder 1.5.
+// Returns ErrDivisionByZero if x is 0, ErrOverflow if q does not fit in int64.
+func (this Decimal4) QuoRem(x Decimal4) (q int64, r Decimal4, err error) {
+	if x == 0 {
+		return 0, 0, ErrDivisionByZero
+	}
+	if x == -1 && this == math.MinInt64 {
+		return 0, 0, ErrOverflow
+	}
+	return int64(this / x), this % x, nil
+}
+
+// ErrDivisorPlaces is returned by DivMod when the divisor has more decimal places than 4 - places,
+// so q*x would need more than 4 decimal places.
+var ErrDivisorPlaces = errors.New("decimal4: divisor has too many decimal places")
+
+// DivMod returns the quotient q of this / x rounded to places (0-4) decimal places using mode,
+// and the remainder r so that q*x + r == this exactly. For example 100 / 3 to 2 places RoundDown
+// is 33.33 remainder .01, so the last of 3 installments is 33.33 + .01.
+// x must have at most 4 - places decimal places (a whole number of installments always works),
+// otherwise DivMod returns ErrDivisorPlaces, for example 100 / 3.0001 to 2 places.
+// Returns ErrDivisionByZero if x is 0, ErrOverflow if a result does not fit. Panics if places is not 0-4.
+func (this Decimal4) DivMod(x Decimal4, places int, mode RoundingMode) (q, r Decimal4, err error) {
+	if places < 0 || places > 4 {
+		log.Panic("Decimal4 DivMod invalid places=", places)
+	}
+	if x == 0 {
+		return 0, 0, ErrDivisionByZero
+	}
+	if int64(x)%pow10[places] != 0 {
+		return 0, 0, ErrDivisorPlaces
+	}
+	qp, ok := mulDiv(int64(this), pow10[places], int64(x), mode) // quotient in units of 10^-places
+	if !ok {
+		return 0, 0, ErrOverflow
+	}
+	unit := pow10[4-places]
+	if qp > math.MaxInt64/unit || qp < math.MinInt64/unit {
+		return 0, 0, ErrOverflow
+	}
+	// q*x in Decimal4 units is qp * (x / 10^places), exact because x is a multiple of 10^places
+	product := new(big.Int).Mul(big.NewInt(qp), big.NewInt(int64(x)/pow10[places]))
+	diff := product.Sub(big.NewInt(int64(this)), product)
+	if !diff.IsInt64() {
+		return 0, 0, ErrOverflow
+	}
+	return Decimal4(qp * unit), Decimal4(diff.Int64()), nil
+}
+
+// Mod returns this modulo x, with the sign of x (floored division), for example
+// -7 mod 3 is 2 and 7 mod -3 is -2. Returns ErrDivisionByZero if x is 0.
+func (this Decimal4) Mod(x Decimal4) (Decimal4, error) {
+	if x == 0 {
+		return 0, ErrDivisionByZero
+	}
+	r := this % x
+	if r != 0 && (r < 0) != (x < 0) {
+		r += x
+	}
+	return r, nil
+}
+
+// Rem returns the remainder of this / x, with the sign of this (truncated division, like Go %),
+// for example -7 rem 3 is -1 and 7 rem -3 is 1. Returns ErrDivisionByZero if x is 0.
+func (this Decimal4) Rem(x Decimal4) (Decimal4, error) {
+	if x == 0 {
+		return 0, ErrDivisionByZero
+	}
+	return this % x, nil
+}
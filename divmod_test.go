@@ -0,0 +1,108 @@
+package decimal4
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuoRem(t *testing.T) {
+	type input struct {
+		x, y float64
+		q    int64
+		r    float64
+	}
+	data := []input{
+		{10.5, 3, 3, 1.5},
+		{-10.5, 3, -3, -1.5},
+		{10.5, -3, -3, 1.5},
+		{1, .3, 3, .1},
+		{.0005, .0002, 2, .0001},
+		{2, 4, 0, 2},
+	}
+	for i, v := range data {
+		q, r, err := New(v.x).QuoRem(New(v.y))
+		if err != nil || q != v.q || r != New(v.r) {
+			t.Errorf("data[%d]: should be %d %f, but is %d %s %v", i, v.q, v.r, q, r, err)
+		}
+	}
+	if _, _, err := New(1).QuoRem(0); err != ErrDivisionByZero {
+		t.Error("expected ErrDivisionByZero, got", err)
+	}
+	if _, _, err := Decimal4(math.MinInt64).QuoRem(-1); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+}
+
+func TestDivMod(t *testing.T) {
+	type input struct {
+		x, y   float64
+		places int
+		mode   RoundingMode
+		q, r   float64
+	}
+	data := []input{
+		{100, 3, 2, RoundDown, 33.33, .01},
+		{100, 3, 2, RoundUp, 33.34, -.02},
+		{100, 3, 0, RoundHalfUp, 33, 1},
+		{-100, 3, 2, RoundDown, -33.33, -.01},
+		{-100, 3, 2, RoundFloor, -33.34, .02},
+		{10, .3, 1, RoundHalfUp, 33.3, .01},
+		{7.5, 2.5, 1, RoundHalfUp, 3, 0},
+		{7.5, 2, 4, RoundHalfUp, 3.75, 0},
+		{100, 3.0001, 0, RoundDown, 33, 0.9967},
+		{100, 3.01, 2, RoundHalfUp, 33.22, .0078},
+	}
+	for i, v := range data {
+		q, r, err := New(v.x).DivMod(New(v.y), v.places, v.mode)
+		if err != nil || q != New(v.q) || r != New(v.r) {
+			t.Errorf("data[%d]: should be %f %f, but is %s %s %v", i, v.q, v.r, q, r, err)
+		}
+		if q.Multiply(New(v.y))+r != New(v.x) {
+			t.Errorf("data[%d]: q*x + r != this", i)
+		}
+	}
+	for _, v := range []struct {
+		x      float64
+		places int
+	}{{.0003, 2}, {3.0001, 2}, {2.5, 4}, {3.01, 3}} {
+		if _, _, err := New(100).DivMod(New(v.x), v.places, RoundDown); err != ErrDivisorPlaces {
+			t.Errorf("DivMod by %f to %d places expected ErrDivisorPlaces, got %v", v.x, v.places, err)
+		}
+	}
+	if _, _, err := New(1).DivMod(0, 2, RoundDown); err != ErrDivisionByZero {
+		t.Error("expected ErrDivisionByZero, got", err)
+	}
+	if _, _, err := Decimal4(math.MaxInt64).DivMod(New(.5), 0, RoundDown); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+}
+
+func TestModRem(t *testing.T) {
+	type input struct {
+		x, y     float64
+		mod, rem float64
+	}
+	data := []input{
+		{7, 3, 1, 1},
+		{-7, 3, 2, -1},
+		{7, -3, -2, 1},
+		{-7, -3, -1, -1},
+		{7.5, 2.5, 0, 0},
+		{-7.5, 2.5, 0, 0},
+		{1.2345, .5, .2345, .2345},
+		{-1.2345, .5, .2655, -.2345},
+	}
+	for i, v := range data {
+		mod, err1 := New(v.x).Mod(New(v.y))
+		rem, err2 := New(v.x).Rem(New(v.y))
+		if err1 != nil || err2 != nil || mod != New(v.mod) || rem != New(v.rem) {
+			t.Errorf("data[%d]: should be %f %f, but is %s %s", i, v.mod, v.rem, mod, rem)
+		}
+	}
+	if _, err := New(1).Mod(0); err != ErrDivisionByZero {
+		t.Error("Mod expected ErrDivisionByZero, got", err)
+	}
+	if _, err := New(1).Rem(0); err != ErrDivisionByZero {
+		t.Error("Rem expected ErrDivisionByZero, got", err)
+	}
+}
@@ -0,0 +1,22 @@
+package decimal4
+
+import "errors"
+
+// ErrOverflow is returned when a result does not fit in int64.
+var ErrOverflow = errors.New("decimal4: overflow")
+
+// ErrInexact is returned when a conversion would lose digits.
+var ErrInexact = errors.New("decimal4: result is not exact")
+
+// ErrDivisionByZero is returned when a divisor is zero.
+var ErrDivisionByZero = errors.New("decimal4: division by zero")
+
+// ErrEmpty is returned when a calculation needs at least one value.
+var ErrEmpty = errors.New("decimal4: no values")
+
+// ErrLength is returned when slices that must pair up have different lengths.
+var ErrLength = errors.New("decimal4: slices have different lengths")
+
+// ErrDomain is returned when an argument is outside the domain of a function,
+// for example the square root of a negative number.
+var ErrDomain = errors.New("decimal4: argument out of domain")
@@ -0,0 +1,117 @@
+package decimal4
+
+import "errors"
+
+var (
+	ErrEuroRate        = errors.New("decimal4: euro conversion rate must be > 0 with at most 6 significant digits")
+	ErrEuroInverseRate = errors.New("decimal4: euro conversion rates must be 1 EUR = rate, inverse and cross rates are not allowed")
+	ErrEuroCurrency    = errors.New("decimal4: no euro conversion rate for currency")
+)
+
+// EuroRates holds the irrevocable conversion rates of legacy currencies, 1 EUR = rate.
+var EuroRates = map[string]Decimal6{
+	"ATS": 13760300,   // 13.7603
+	"BEF": 40339900,   // 40.3399
+	"CYP": 585274,     // .585274
+	"DEM": 1955830,    // 1.95583
+	"EEK": 15646600,   // 15.6466
+	"ESP": 166386000,  // 166.386
+	"FIM": 5945730,    // 5.94573
+	"FRF": 6559570,    // 6.55957
+	"GRD": 340750000,  // 340.750
+	"HRK": 7534500,    // 7.53450
+	"IEP": 787564,     // .787564
+	"ITL": 1936270000, // 1936.27
+	"LTL": 3452800,    // 3.45280
+	"LUF": 40339900,   // 40.3399
+	"LVL": 702804,     // .702804
+	"MTL": 429300,     // .429300
+	"NLG": 2203710,    // 2.20371
+	"PTE": 200482000,  // 200.482
+	"SIT": 239640000,  // 239.640
+	"SKK": 30126000,   // 30.1260
+}
+
+// EuroConversion is the result of EuroConverter.Convert.
+// Euro is the intermediate euro amount when converting between two legacy currencies,
+// or the euro side of the conversion otherwise.
+type EuroConversion struct {
+	From   string
+	To     string
+	Amount Decimal4
+	Euro   Decimal4
+	Result Decimal4
+}
+
+// EuroConverter converts amounts following EU Regulation 1103/97:
+// rates have 6 significant digits and are never rounded or inverted,
+// legacy to euro divides by the rate, legacy to legacy goes through euro
+// rounded to EuroPlaces (at least 3), and results are rounded half up
+// to the minor units of the target currency (CurrencyPlaces).
+type EuroConverter struct {
+	EuroPlaces int // places of the intermediate euro amount, 3 or 4
+	rates      map[string]Decimal6
+}
+
+// NewEuroConverter returns an EuroConverter loaded with EuroRates and EuroPlaces 3.
+func NewEuroConverter() *EuroConverter {
+	this := &EuroConverter{EuroPlaces: 3, rates: make(map[string]Decimal6)}
+	for currency, rate := range EuroRates {
+		this.rates[currency] = rate
+	}
+	return this
+}
+
+// SetRate sets the conversion rate 1 base = rate quote.
+// Base must be "EUR"; inverse (quote "EUR") and cross rates return ErrEuroInverseRate.
+func (this *EuroConverter) SetRate(base, quote string, rate Decimal6) error {
+	if base != "EUR" || quote == "EUR" {
+		return ErrEuroInverseRate
+	}
+	if rate <= 0 || rate.RoundSig(6, RoundDown) != rate {
+		return ErrEuroRate
+	}
+	if this.rates == nil {
+		this.rates = make(map[string]Decimal6)
+	}
+	this.rates[quote] = rate
+	return nil
+}
+
+// Convert returns amount in currency from converted to currency to.
+func (this *EuroConverter) Convert(amount Decimal4, from, to string) (EuroConversion, error) {
+	result := EuroConversion{From: from, To: to, Amount: amount}
+	euro := amount
+	if from != "EUR" {
+		rate, found := this.rates[from]
+		if !found {
+			return result, ErrEuroCurrency
+		}
+		places := this.EuroPlaces
+		if to == "EUR" {
+			places = CurrencyPlaces("EUR")
+		} else if places < 3 || places > 4 {
+			return result, ErrEuroRate
+		}
+		var ok bool
+		euro, ok = Rounding{Places: places, Mode: RoundHalfUp}.mulDiv(int64(amount), 1000000, int64(rate))
+		if !ok {
+			return result, ErrOverflow
+		}
+	}
+	result.Euro = euro
+	if to == "EUR" {
+		result.Result = euro
+		return result, nil
+	}
+	rate, found := this.rates[to]
+	if !found {
+		return result, ErrEuroCurrency
+	}
+	converted, ok := Rounding{Places: CurrencyPlaces(to), Mode: RoundHalfUp}.mulDiv(int64(euro), int64(rate), 1000000)
+	if !ok {
+		return result, ErrOverflow
+	}
+	result.Result = converted
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package decimal4
+
+import "testing"
+
+func TestEuroConverter(t *testing.T) {
+	ec := NewEuroConverter()
+	type input struct {
+		amount float64
+		from   string
+		to     string
+		euro   float64
+		result float64
+	}
+	data := []input{
+		{100, "EUR", "DEM", 100, 195.58},
+		{100, "DEM", "EUR", 51.13, 51.13},    // 51.129188
+		{1000, "FRF", "EUR", 152.45, 152.45}, // 152.449017
+		{1000, "EUR", "ITL", 1000, 1936270},
+		{100, "DEM", "FRF", 51.129, 335.38}, // 51.129 * 6.55957 = 335.384
+		{1000, "ITL", "DEM", .516, 1.01},    // .516457 -> .516, * 1.95583 = 1.0092
+		{-100, "DEM", "FRF", -51.129, -335.38},
+	}
+	for i, v := range data {
+		c, err := ec.Convert(New(v.amount), v.from, v.to)
+		if err != nil || c.Euro != New(v.euro) || c.Result != New(v.result) {
+			t.Errorf("data[%d]: euro, result should be %f, %f, but are %s, %s, %v", i, v.euro, v.result, c.Euro, c.Result, err)
+		}
+	}
+
+	ec.EuroPlaces = 4
+	if c, _ := ec.Convert(New(1000), "ITL", "DEM"); c.Euro != New(.5165) {
+		t.Errorf("EuroPlaces 4: euro should be .5165, but is %s", c.Euro)
+	}
+	if err := ec.SetRate("DEM", "EUR", NewDecimal6(.511292)); err != ErrEuroInverseRate {
+		t.Error("expected ErrEuroInverseRate, got", err)
+	}
+	if err := ec.SetRate("DEM", "FRF", NewDecimal6(3.35386)); err != ErrEuroInverseRate {
+		t.Error("expected ErrEuroInverseRate for cross rate, got", err)
+	}
+	if err := ec.SetRate("EUR", "XXX", NewDecimal6(1.234567)); err != ErrEuroRate {
+		t.Error("expected ErrEuroRate for 7 significant digits, got", err)
+	}
+	if _, err := ec.Convert(New(1), "USD", "EUR"); err != ErrEuroCurrency {
+		t.Error("expected ErrEuroCurrency, got", err)
+	}
+}
@@ -0,0 +1,342 @@
+package decimal4
+
+import (
+	"log"
+	"math/big"
+	"strconv"
+)
+
+// Scale sets the number of implied decimal places of a Fixed type.
+// Scale0 through Scale9 are provided.
+type Scale interface {
+	Places() int
+}
+
+type (
+	Scale0 struct{}
+	Scale1 struct{}
+	Scale2 struct{}
+	Scale3 struct{}
+	Scale4 struct{}
+	Scale5 struct{}
+	Scale6 struct{}
+	Scale7 struct{}
+	Scale8 struct{}
+	Scale9 struct{}
+)
+
+func (Scale0) Places() int { return 0 }
+func (Scale1) Places() int { return 1 }
+func (Scale2) Places() int { return 2 }
+func (Scale3) Places() int { return 3 }
+func (Scale4) Places() int { return 4 }
+func (Scale5) Places() int { return 5 }
+func (Scale6) Places() int { return 6 }
+func (Scale7) Places() int { return 7 }
+func (Scale8) Places() int { return 8 }
+func (Scale9) Places() int { return 9 }
+
+// Fixed is an int64 value with S.Places() implied decimal places.
+// Arithmetic methods are checked and return ErrOverflow or ErrDivisionByZero instead of panicking.
+//
+// Decimal4 and Decimal6 are the Scale4 and Scale6 members of the family. They remain defined types,
+// not aliases, because Go does not allow methods on an alias of one instantiation, and they keep their
+// panicking methods (Multiply, Divide, ...) and their encoding, the stored int64 in JSON.
+// Their parse, checked arithmetic and rounding methods call the Fixed implementation,
+// and they convert directly: Fixed[Scale4](d4), Decimal4(f4), Fixed[Scale6](d6), Decimal6(f6).
+type Fixed[S Scale] int64
+
+// Decimal types for the other scales.
+type (
+	Decimal0 = Fixed[Scale0]
+	Decimal1 = Fixed[Scale1]
+	Decimal2 = Fixed[Scale2] // cents
+	Decimal3 = Fixed[Scale3]
+	Decimal5 = Fixed[Scale5]
+	Decimal7 = Fixed[Scale7]
+	Decimal8 = Fixed[Scale8] // satoshis
+	Decimal9 = Fixed[Scale9]
+)
+
+func places[S Scale]() int {
+	var s S
+	return s.Places()
+}
+
+// ParseFixed returns the value of s, for example "-1234.56".
+// Returns ErrParse if s is not a decimal number or has non-zero digits beyond the scale,
+// ErrOverflow if it does not fit.
+func ParseFixed[S Scale](s string) (Fixed[S], error) {
+	b, err := parseScaled(s, places[S]())
+	if err != nil {
+		return 0, err
+	}
+	if !b.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return Fixed[S](b.Int64()), nil
+}
+
+// Places returns the number of implied decimal places.
+func (this Fixed[S]) Places() int {
+	return places[S]()
+}
+
+// String returns this with all decimal places.
+func (this Fixed[S]) String() string {
+	return formatScaled(big.NewInt(int64(this)), places[S](), places[S]())
+}
+
+// Fmt returns this formatted like Decimal4.Fmt, with width.precision
+// and comma thousands separators. Optional currency symbol is prefixed.
+func (this Fixed[S]) Fmt(widthPrecision float64, currency ...string) string {
+	return fmtScaled(big.NewInt(int64(this)), places[S](), widthPrecision, currency)
+}
+
+// Add returns this + x.
+func (this Fixed[S]) Add(x Fixed[S]) (Fixed[S], error) {
+	a := this + x
+	if (x > 0 && a < this) || (x < 0 && a > this) {
+		return 0, ErrOverflow
+	}
+	return a, nil
+}
+
+// Sub returns this - x.
+func (this Fixed[S]) Sub(x Fixed[S]) (Fixed[S], error) {
+	a := this - x
+	if (x > 0 && a > this) || (x < 0 && a < this) {
+		return 0, ErrOverflow
+	}
+	return a, nil
+}
+
+// Neg returns -this.
+func (this Fixed[S]) Neg() (Fixed[S], error) {
+	if this == -this && this != 0 { // math.MinInt64
+		return 0, ErrOverflow
+	}
+	return -this, nil
+}
+
+// Abs returns the absolute value of this.
+func (this Fixed[S]) Abs() (Fixed[S], error) {
+	if this < 0 {
+		return this.Neg()
+	}
+	return this, nil
+}
+
+// Mul returns this * x, rounded using mode.
+func (this Fixed[S]) Mul(x Fixed[S], mode RoundingMode) (Fixed[S], error) {
+	return Mul(this, x, mode)
+}
+
+// MulInt returns this * x.
+func (this Fixed[S]) MulInt(x int64) (Fixed[S], error) {
+	a, ok := mulDiv(int64(this), x, 1, RoundDown)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return Fixed[S](a), nil
+}
+
+// Div returns this / x, rounded using mode.
+func (this Fixed[S]) Div(x Fixed[S], mode RoundingMode) (Fixed[S], error) {
+	return Div(this, x, mode)
+}
+
+// DivInt returns this / x, rounded using mode.
+func (this Fixed[S]) DivInt(x int64, mode RoundingMode) (Fixed[S], error) {
+	if x == 0 {
+		return 0, ErrDivisionByZero
+	}
+	a, ok := mulDiv(int64(this), 1, x, mode)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return Fixed[S](a), nil
+}
+
+// Round returns this rounded to p decimal places (0 to Places()) using mode.
+func (this Fixed[S]) Round(p int, mode RoundingMode) (Fixed[S], error) {
+	drop := places[S]() - p
+	if p < 0 || drop < 0 {
+		log.Panic("Fixed Round invalid places=", p)
+	}
+	a, ok := roundToUnit(int64(this), pow10[drop], mode)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return Fixed[S](a), nil
+}
+
+// Mul returns a * b in the scale of a, rounded using mode.
+// For example Mul(Decimal2 amount, Fixed[Scale6] rate) works like Decimal4.Multiply6.
+func Mul[S, T Scale](a Fixed[S], b Fixed[T], mode RoundingMode) (Fixed[S], error) {
+	x, ok := mulDiv(int64(a), int64(b), pow10[places[T]()], mode)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return Fixed[S](x), nil
+}
+
+// Div returns a / b in the scale of a, rounded using mode.
+func Div[S, T Scale](a Fixed[S], b Fixed[T], mode RoundingMode) (Fixed[S], error) {
+	if b == 0 {
+		return 0, ErrDivisionByZero
+	}
+	x, ok := mulDiv(int64(a), pow10[places[T]()], int64(b), mode)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return Fixed[S](x), nil
+}
+
+// Rescale returns x with the scale of T, rounded using mode if T has fewer places.
+// For example Rescale[Scale2](Fixed[Scale4](d4), RoundHalfEven).
+func Rescale[T, S Scale](x Fixed[S], mode RoundingMode) (Fixed[T], error) {
+	from, to := places[S](), places[T]()
+	var a int64
+	var ok bool
+	if to >= from {
+		a, ok = mulDiv(int64(x), pow10[to-from], 1, mode)
+	} else {
+		a, ok = mulDiv(int64(x), 1, pow10[from-to], mode)
+	}
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return Fixed[T](a), nil
+}
+
+// MarshalText returns this as a decimal string, for example "12.34".
+func (this Fixed[S]) MarshalText() ([]byte, error) {
+	return []byte(this.String()), nil
+}
+
+// UnmarshalText sets this from a decimal string, see ParseFixed.
+func (this *Fixed[S]) UnmarshalText(text []byte) error {
+	x, err := ParseFixed[S](string(text))
+	if err != nil {
+		return err
+	}
+	*this = x
+	return nil
+}
+
+// MarshalJSON returns this as a JSON number with all decimal places, for example 12.34.
+func (this Fixed[S]) MarshalJSON() ([]byte, error) {
+	return []byte(this.String()), nil
+}
+
+// UnmarshalJSON sets this from a JSON number or string, see ParseFixed.
+func (this *Fixed[S]) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if len(s) > 1 && s[0] == '"' {
+		var err error
+		if s, err = strconv.Unquote(s); err != nil {
+			return ErrParse
+		}
+	}
+	return this.UnmarshalText([]byte(s))
+}
+
+// ParseDecimal4 returns the value of s, for example "-1234.5678", see ParseFixed.
+func ParseDecimal4(s string) (Decimal4, error) {
+	x, err := ParseFixed[Scale4](s)
+	return Decimal4(x), err
+}
+
+// ParseDecimal6 returns the value of s, for example "1.234567", see ParseFixed.
+func ParseDecimal6(s string) (Decimal6, error) {
+	x, err := ParseFixed[Scale6](s)
+	return Decimal6(x), err
+}
+
+// Add returns this + x, or ErrOverflow.
+func (this Decimal4) Add(x Decimal4) (Decimal4, error) {
+	a, err := Fixed[Scale4](this).Add(Fixed[Scale4](x))
+	return Decimal4(a), err
+}
+
+// Sub returns this - x, or ErrOverflow.
+func (this Decimal4) Sub(x Decimal4) (Decimal4, error) {
+	a, err := Fixed[Scale4](this).Sub(Fixed[Scale4](x))
+	return Decimal4(a), err
+}
+
+// Mul returns this * x rounded using mode, or ErrOverflow. See Multiply for the panicking version.
+func (this Decimal4) Mul(x Decimal4, mode RoundingMode) (Decimal4, error) {
+	a, err := Fixed[Scale4](this).Mul(Fixed[Scale4](x), mode)
+	return Decimal4(a), err
+}
+
+// MulInt returns this * x, or ErrOverflow.
+func (this Decimal4) MulInt(x int64) (Decimal4, error) {
+	a, err := Fixed[Scale4](this).MulInt(x)
+	return Decimal4(a), err
+}
+
+// Div returns this / x rounded using mode, or ErrDivisionByZero or ErrOverflow.
+func (this Decimal4) Div(x Decimal4, mode RoundingMode) (Decimal4, error) {
+	a, err := Fixed[Scale4](this).Div(Fixed[Scale4](x), mode)
+	return Decimal4(a), err
+}
+
+// DivInt returns this / x rounded using mode, or ErrDivisionByZero or ErrOverflow.
+func (this Decimal4) DivInt(x int64, mode RoundingMode) (Decimal4, error) {
+	a, err := Fixed[Scale4](this).DivInt(x, mode)
+	return Decimal4(a), err
+}
+
+// Round returns this rounded to p decimal places (0-4) using mode, or ErrOverflow.
+func (this Decimal4) Round(p int, mode RoundingMode) (Decimal4, error) {
+	a, err := Fixed[Scale4](this).Round(p, mode)
+	return Decimal4(a), err
+}
+
+// Add returns this + x, or ErrOverflow.
+func (this Decimal6) Add(x Decimal6) (Decimal6, error) {
+	a, err := Fixed[Scale6](this).Add(Fixed[Scale6](x))
+	return Decimal6(a), err
+}
+
+// Sub returns this - x, or ErrOverflow.
+func (this Decimal6) Sub(x Decimal6) (Decimal6, error) {
+	a, err := Fixed[Scale6](this).Sub(Fixed[Scale6](x))
+	return Decimal6(a), err
+}
+
+// Mul returns this * x rounded using mode, or ErrOverflow.
+func (this Decimal6) Mul(x Decimal6, mode RoundingMode) (Decimal6, error) {
+	a, err := Fixed[Scale6](this).Mul(Fixed[Scale6](x), mode)
+	return Decimal6(a), err
+}
+
+// MulInt returns this * x, or ErrOverflow.
+func (this Decimal6) MulInt(x int64) (Decimal6, error) {
+	a, err := Fixed[Scale6](this).MulInt(x)
+	return Decimal6(a), err
+}
+
+// Div returns this / x rounded using mode, or ErrDivisionByZero or ErrOverflow.
+func (this Decimal6) Div(x Decimal6, mode RoundingMode) (Decimal6, error) {
+	a, err := Fixed[Scale6](this).Div(Fixed[Scale6](x), mode)
+	return Decimal6(a), err
+}
+
+// DivInt returns this / x rounded using mode, or ErrDivisionByZero or ErrOverflow.
+func (this Decimal6) DivInt(x int64, mode RoundingMode) (Decimal6, error) {
+	a, err := Fixed[Scale6](this).DivInt(x, mode)
+	return Decimal6(a), err
+}
+
+// Round returns this rounded to p decimal places (0-6) using mode, or ErrOverflow.
+func (this Decimal6) Round(p int, mode RoundingMode) (Decimal6, error) {
+	a, err := Fixed[Scale6](this).Round(p, mode)
+	return Decimal6(a), err
+}
@@ -0,0 +1,187 @@
+package decimal4
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestFixedParseFormat(t *testing.T) {
+	cents, err := ParseFixed[Scale2]("-1234.5")
+	if err != nil || cents != -123450 || cents.String() != "-1234.50" || cents.Places() != 2 {
+		t.Errorf("ParseFixed should be -1234.50, but is %s, %v", cents, err)
+	}
+	if s := cents.Fmt(10.2, Dollar); s != "$-1,234.50" {
+		t.Errorf("Fmt expected:$-1,234.50   got:%s", s)
+	}
+	if _, err := ParseFixed[Scale2]("1.234"); err != ErrParse {
+		t.Error("expected ErrParse, got", err)
+	}
+	if _, err := ParseFixed[Scale9]("9300000000"); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+	if s := Decimal0(42).String(); s != "42" {
+		t.Errorf("Decimal0 String should be 42, but is %s", s)
+	}
+	if s := Decimal8(1).String(); s != "0.00000001" {
+		t.Errorf("Decimal8 String should be 0.00000001, but is %s", s)
+	}
+}
+
+func TestFixedArithmetic(t *testing.T) {
+	a, b := Decimal2(1050), Decimal2(300) // 10.50, 3.00
+	type result struct {
+		name   string
+		value  Decimal2
+		err    error
+		expect Decimal2
+	}
+	sum, err1 := a.Add(b)
+	diff, err2 := a.Sub(b)
+	product, err3 := a.Mul(b, RoundHalfUp)
+	quotient, err4 := a.Div(b, RoundHalfUp)
+	third, err5 := Decimal2(100).Div(b, RoundDown)
+	rounded, err6 := Decimal2(1050).Round(0, RoundHalfEven)
+	data := []result{
+		{"Add", sum, err1, 1350},
+		{"Sub", diff, err2, 750},
+		{"Mul", product, err3, 3150},
+		{"Div", quotient, err4, 350},
+		{"Div RoundDown", third, err5, 33},
+		{"Round", rounded, err6, 1000},
+	}
+	for _, v := range data {
+		if v.err != nil || v.value != v.expect {
+			t.Errorf("%s should be %s, but is %s, %v", v.name, v.expect, v.value, v.err)
+		}
+	}
+
+	max := Decimal2(math.MaxInt64)
+	if _, err := max.Add(1); err != ErrOverflow {
+		t.Error("Add: expected ErrOverflow, got", err)
+	}
+	if _, err := Decimal2(math.MinInt64).Sub(1); err != ErrOverflow {
+		t.Error("Sub: expected ErrOverflow, got", err)
+	}
+	if _, err := Decimal2(math.MinInt64).Neg(); err != ErrOverflow {
+		t.Error("Neg: expected ErrOverflow, got", err)
+	}
+	if _, err := max.MulInt(2); err != ErrOverflow {
+		t.Error("MulInt: expected ErrOverflow, got", err)
+	}
+	if _, err := a.Div(0, RoundHalfUp); err != ErrDivisionByZero {
+		t.Error("Div: expected ErrDivisionByZero, got", err)
+	}
+	if _, err := a.DivInt(0, RoundHalfUp); err != ErrDivisionByZero {
+		t.Error("DivInt: expected ErrDivisionByZero, got", err)
+	}
+}
+
+func TestFixedCrossScale(t *testing.T) {
+	amount := Decimal2(1234567)                // 12,345.67
+	rate := Fixed[Scale6](NewDecimal6(.03125)) // Decimal6 converts directly
+	interest, err := Mul(amount, rate, RoundHalfUp)
+	if err != nil || interest != 38580 { // 385.802...
+		t.Errorf("Mul should be 385.80, but is %s, %v", interest, err)
+	}
+	d4 := New(1234.5678)
+	if x, _ := Mul(Fixed[Scale4](d4), rate, RoundHalfUp); Decimal4(x) != d4.Multiply6(NewDecimal6(.03125)) {
+		t.Error("Mul should match Decimal4.Multiply6")
+	}
+	perUnit, err := Div(amount, Decimal3(2500), RoundHalfUp) // 12,345.67 / 2.5
+	if err != nil || perUnit != 493827 {
+		t.Errorf("Div should be 4938.27, but is %s, %v", perUnit, err)
+	}
+
+	cents, err := Rescale[Scale2](Fixed[Scale4](New(1.235)), RoundHalfEven)
+	if err != nil || cents != 124 {
+		t.Errorf("Rescale down should be 1.24, but is %s, %v", cents, err)
+	}
+	sats, err := Rescale[Scale8](Decimal2(150), RoundDown)
+	if err != nil || sats != 150000000 {
+		t.Errorf("Rescale up should be 1.50000000, but is %s, %v", sats, err)
+	}
+	if _, err := Rescale[Scale9](Decimal0(math.MaxInt64/100), RoundDown); err != ErrOverflow {
+		t.Error("Rescale: expected ErrOverflow, got", err)
+	}
+}
+
+func TestFixedJSON(t *testing.T) {
+	type line struct {
+		Price Decimal2       `json:"price"`
+		Rate  Fixed[Scale6]  `json:"rate"`
+		Qty   *Fixed[Scale3] `json:"qty"`
+	}
+	in := line{Price: -1999, Rate: 31250}
+	encoded, err := json.Marshal(in)
+	if err != nil || string(encoded) != `{"price":-19.99,"rate":0.031250,"qty":null}` {
+		t.Errorf("Marshal got %s, %v", encoded, err)
+	}
+	var out line
+	if err := json.Unmarshal([]byte(`{"price":"-19.99","rate":0.03125,"qty":1.5}`), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Price != in.Price || out.Rate != in.Rate || *out.Qty != 1500 {
+		t.Errorf("Unmarshal got %+v", out)
+	}
+	if err := json.Unmarshal([]byte(`{"price":1.999}`), &out); err == nil {
+		t.Error("Unmarshal should fail for too many decimal places")
+	}
+}
+
+func TestDecimal4Decimal6Family(t *testing.T) {
+	if x, err := ParseDecimal4("-1234.5678"); err != nil || x != -12345678 {
+		t.Error("ParseDecimal4 should be -1234.5678, but is", x, err)
+	}
+	if _, err := ParseDecimal4("1.23456"); err != ErrParse {
+		t.Error("expected ErrParse, got", err)
+	}
+	if x, err := ParseDecimal6("0.075"); err != nil || x != 75000 {
+		t.Error("ParseDecimal6 should be 0.075, but is", x, err)
+	}
+
+	a, b := New(10.5), New(3)
+	if x, err := a.Add(b); err != nil || x != New(13.5) {
+		t.Error("Add should be 13.5, but is", x, err)
+	}
+	if _, err := MaxDecimal4.Add(1); err != ErrOverflow {
+		t.Error("Add expected ErrOverflow, got", err)
+	}
+	if x, err := a.Sub(b); err != nil || x != New(7.5) {
+		t.Error("Sub should be 7.5, but is", x, err)
+	}
+	if x, err := a.Mul(b, RoundHalfUp); err != nil || x != a.Multiply(b) {
+		t.Error("Mul should be 31.5, but is", x, err)
+	}
+	if _, err := MaxDecimal4.MulInt(2); err != ErrOverflow {
+		t.Error("MulInt expected ErrOverflow, got", err)
+	}
+	if x, err := a.Div(b, RoundHalfUp); err != nil || x != a.Divide(b) {
+		t.Error("Div should be 3.5, but is", x, err)
+	}
+	if _, err := a.DivInt(0, RoundHalfUp); err != ErrDivisionByZero {
+		t.Error("DivInt expected ErrDivisionByZero, got", err)
+	}
+	if x, err := New(2.345).Round(2, RoundHalfEven); err != nil || x != New(2.34) {
+		t.Error("Round should be 2.34, but is", x, err)
+	}
+	if x, err := NewDecimal6(1.5).Mul(NewDecimal6(.075), RoundHalfUp); err != nil || x != NewDecimal6(.1125) {
+		t.Error("Decimal6 Mul should be 0.1125, but is", x, err)
+	}
+
+	// encoding is unchanged, the stored value as before
+	type amounts struct {
+		Price Decimal4            `json:"price"`
+		Rate  Decimal6            `json:"rate"`
+		Keys  map[Decimal4]string `json:"keys"`
+	}
+	v := amounts{New(56.27), NewDecimal6(.075), map[Decimal4]string{New(1): "a"}}
+	encoded, err := json.Marshal(v)
+	if err != nil || string(encoded) != `{"price":562700,"rate":75000,"keys":{"10000":"a"}}` {
+		t.Fatalf("json should be stored values, but is %s %v", encoded, err)
+	}
+	var decoded amounts
+	if err := json.Unmarshal([]byte(`{"price":123400}`), &decoded); err != nil || decoded.Price != New(12.34) {
+		t.Error("json 123400 should be 12.34, but is", decoded.Price, err)
+	}
+}
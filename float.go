@@ -0,0 +1,73 @@
+package decimal4
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrNaN is returned when a float64 is NaN.
+var ErrNaN = errors.New("decimal4: NaN")
+
+// ErrInfinity is returned when a float64 is +Inf or -Inf.
+var ErrInfinity = errors.New("decimal4: infinity")
+
+// fromFloat returns the shortest decimal representation of x, the digits strconv.FormatFloat(x, 'g', -1, 64)
+// prints, with places implied decimal places, rounded using mode.
+func fromFloat(x float64, places int, mode RoundingMode) (int64, error) {
+	switch {
+	case math.IsNaN(x):
+		return 0, ErrNaN
+	case math.IsInf(x, 0):
+		return 0, ErrInfinity
+	case math.Abs(x) >= 1e19:
+		return 0, ErrOverflow
+	}
+	// 'e' gives the same shortest digits as 'g', always as d.ddde±dd
+	s := strconv.FormatFloat(x, 'e', -1, 64)
+	e := strings.IndexByte(s, 'e')
+	exp, _ := strconv.Atoi(s[e+1:])
+	digits := strings.Replace(s[:e], ".", "", 1)
+	n, _ := new(big.Int).SetString(digits, 10)
+	shift := exp - (len(strings.TrimLeft(digits, "-")) - 1) + places
+	if shift >= 0 {
+		n.Mul(n, bigPow10(shift))
+	} else {
+		n = bigQuo(n, bigPow10(-shift), mode)
+	}
+	if !n.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return n.Int64(), nil
+}
+
+// FromFloat64 returns x rounded to 4 decimal places using mode. Unlike New, which adds .00003 and truncates,
+// x is first converted to the shortest decimal that converts back to x (as printed by
+// strconv.FormatFloat(x, 'g', -1, 64)), then rounded once, so 2.00005 rounds half up to 2.0001.
+// Returns ErrNaN, ErrInfinity, or ErrOverflow if the result does not fit.
+func FromFloat64(x float64, mode RoundingMode) (Decimal4, error) {
+	a, err := fromFloat(x, 4, mode)
+	return Decimal4(a), err
+}
+
+// Decimal6FromFloat64 returns x rounded to 6 decimal places using mode, like FromFloat64.
+// Unlike NewDecimal6, which adds .0000003 and truncates, the shortest decimal of x is rounded once.
+// Returns ErrNaN, ErrInfinity, or ErrOverflow if the result does not fit (beyond about ±9.2 trillion).
+func Decimal6FromFloat64(x float64, mode RoundingMode) (Decimal6, error) {
+	a, err := fromFloat(x, 6, mode)
+	return Decimal6(a), err
+}
+
+// Float64 returns the float64 nearest to this when |this| <= 2^53 units (about 900 billion),
+// otherwise within 1 float64 ulp. FromFloat64(x.Float64(), mode) == x for any mode when |x| < 100 billion.
+func (this Decimal4) Float64() float64 {
+	return float64(this) / 10000
+}
+
+// Float64 returns the float64 nearest to this when |this| <= 2^53 units (about 9 billion),
+// otherwise within 1 float64 ulp. Decimal6FromFloat64(x.Float64(), mode) == x for any mode when |x| < 1 billion.
+func (this Decimal6) Float64() float64 {
+	return float64(this) / 1000000
+}
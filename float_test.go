@@ -0,0 +1,132 @@
+package decimal4
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestFromFloat64(t *testing.T) {
+	type input struct {
+		x    float64
+		mode RoundingMode
+		want Decimal4
+		err  error
+	}
+	data := []input{
+		{0, RoundHalfUp, 0, nil},
+		{.29, RoundHalfUp, 2900, nil},
+		{-1.1, RoundDown, -11000, nil},
+		{2.00005, RoundHalfUp, 20001, nil},
+		{2.00005, RoundHalfEven, 20000, nil},
+		{2.00015, RoundHalfEven, 20002, nil},
+		{2.00005, RoundDown, 20000, nil},
+		{-2.00005, RoundHalfUp, -20001, nil},
+		{-2.00005, RoundFloor, -20001, nil},
+		{-2.00005, RoundCeiling, -20000, nil},
+		{1e-300, RoundHalfUp, 0, nil},
+		{1e-300, RoundUp, 1, nil},
+		{-1e-300, RoundFloor, -1, nil},
+		{123456789012.3456, RoundHalfUp, 1234567890123456, nil},
+		{922337203685477.5, RoundHalfUp, 9223372036854775000, nil},
+		{-922337203685477.5, RoundHalfUp, -9223372036854775000, nil},
+		{922337203685477.625, RoundHalfUp, 0, ErrOverflow},
+		{1e300, RoundHalfUp, 0, ErrOverflow},
+		{math.NaN(), RoundHalfUp, 0, ErrNaN},
+		{math.Inf(1), RoundHalfUp, 0, ErrInfinity},
+		{math.Inf(-1), RoundHalfUp, 0, ErrInfinity},
+	}
+	for i, v := range data {
+		x, err := FromFloat64(v.x, v.mode)
+		if x != v.want || err != v.err {
+			t.Errorf("data[%d]: FromFloat64(%g) should be %d %v, but is %d %v", i, v.x, v.want, v.err, x, err)
+		}
+	}
+}
+
+func TestDecimal6FromFloat64(t *testing.T) {
+	type input struct {
+		x    float64
+		mode RoundingMode
+		want Decimal6
+		err  error
+	}
+	data := []input{
+		{.29, RoundHalfUp, 290000, nil},
+		{.0000005, RoundHalfUp, 1, nil},
+		{.0000005, RoundHalfEven, 0, nil},
+		{-.0000005, RoundFloor, -1, nil},
+		{1.0000015, RoundHalfEven, 1000002, nil},
+		{1234567.891234, RoundHalfUp, 1234567891234, nil},
+		{9223372036854.775, RoundDown, 9223372036854775000, nil},
+		{9223372036854.777, RoundDown, 0, ErrOverflow},
+		{math.NaN(), RoundHalfUp, 0, ErrNaN},
+		{math.Inf(-1), RoundHalfUp, 0, ErrInfinity},
+	}
+	for i, v := range data {
+		x, err := Decimal6FromFloat64(v.x, v.mode)
+		if x != v.want || err != v.err {
+			t.Errorf("data[%d]: Decimal6FromFloat64(%g) should be %d %v, but is %d %v", i, v.x, v.want, v.err, x, err)
+		}
+	}
+}
+
+// TestFromFloat64BigFloat checks the result is within half a unit (plus half a float64 ulp, the most
+// the shortest decimal can differ from x) of the exact binary value of x.
+func TestFromFloat64BigFloat(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		x := (r.Float64()*2 - 1) * math.Pow(10, float64(r.Intn(18)-8))
+		d4, err4 := FromFloat64(x, RoundHalfEven)
+		d6, err6 := Decimal6FromFloat64(x, RoundHalfEven)
+		if err4 != nil || err6 != nil {
+			t.Fatal(x, err4, err6)
+		}
+		for _, v := range []struct {
+			units int64
+			scale float64
+		}{{int64(d4), 10000}, {int64(d6), 1000000}} {
+			exact := new(big.Float).SetPrec(2000).SetFloat64(x)
+			exact.Mul(exact, big.NewFloat(v.scale))
+			diff := exact.Sub(exact, new(big.Float).SetInt64(v.units))
+			ulp := math.Nextafter(math.Abs(x), math.Inf(1)) - math.Abs(x)
+			bound := big.NewFloat(.5 + ulp*v.scale/2)
+			if diff.Abs(diff).Cmp(bound) > 0 {
+				t.Fatalf("%g with scale %g = %d, off by %s units", x, v.scale, v.units, diff.Text('g', 10))
+			}
+		}
+	}
+}
+
+// TestFloat64BigFloat checks Float64 is the nearest float64 within 2^53 units, and the round trip
+// through FromFloat64 below 10^15 units.
+func TestFloat64BigFloat(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	nearest := func(units int64, scale int64) float64 {
+		q := new(big.Float).SetPrec(2000).SetInt64(units)
+		f, _ := q.Quo(q, new(big.Float).SetInt64(scale)).Float64()
+		return f
+	}
+	for i := 0; i < 100000; i++ {
+		units := r.Int63n(1<<53+1) >> uint(r.Intn(53))
+		if i%2 == 1 {
+			units = -units
+		}
+		if x := Decimal4(units); x.Float64() != nearest(units, 10000) {
+			t.Fatalf("Decimal4(%d).Float64() = %v, nearest is %v", units, x.Float64(), nearest(units, 10000))
+		}
+		if x := Decimal6(units); x.Float64() != nearest(units, 1000000) {
+			t.Fatalf("Decimal6(%d).Float64() = %v, nearest is %v", units, x.Float64(), nearest(units, 1000000))
+		}
+		units %= 1000000000000000
+		for mode := RoundHalfUp; mode <= RoundCeiling; mode++ {
+			if x, err := FromFloat64(Decimal4(units).Float64(), mode); x != Decimal4(units) || err != nil {
+				t.Fatalf("FromFloat64(Decimal4(%d).Float64(), %d) = %d %v", units, mode, int64(x), err)
+			}
+			if x, err := Decimal6FromFloat64(Decimal6(units).Float64(), mode); x != Decimal6(units) || err != nil {
+				t.Fatalf("Decimal6FromFloat64(Decimal6(%d).Float64(), %d) = %d %v", units, mode, int64(x), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package decimal4
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// ErrParse is returned when a string is not a valid decimal number for the target type.
+var ErrParse = errors.New("decimal4: invalid decimal number")
+
+// fmtScaled formats x (with places implied decimal places) like Decimal4.Fmt.
+func fmtScaled(x *big.Int, places int, widthPrecision float64, currency []string) string {
+	width := int(widthPrecision)
+	precision := int(math.Round(widthPrecision*10)) % 10
+	fmtNum := formatScaled(x, places, precision)
+	if len(fmtNum) < width {
+		fmtNum = strings.Repeat(" ", width-len(fmtNum)) + fmtNum
+	}
+	if len(currency) == 0 {
+		thousand := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places+3)), nil)
+		if new(big.Int).Abs(x).Cmp(thousand) < 0 {
+			return fmtNum
+		}
+		return addCommas(fmtNum, "")
+	}
+	return addCommas(fmtNum, currency[0])
+}
+
+// parseScaled returns s as an integer with places implied decimal places.
+func parseScaled(s string, places int) (*big.Int, error) {
+	neg := false
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	whole, frac := s, ""
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		whole, frac = s[:dot], s[dot+1:]
+	}
+	if whole == "" && frac == "" || !isDigits(whole) || !isDigits(frac) {
+		return nil, ErrParse
+	}
+	if len(frac) > places {
+		if strings.Trim(frac[places:], "0") != "" {
+			return nil, ErrParse
+		}
+		frac = frac[:places]
+	}
+	frac += strings.Repeat("0", places-len(frac))
+	b, ok := new(big.Int).SetString("0"+whole+frac, 10)
+	if !ok {
+		return nil, ErrParse
+	}
+	if neg {
+		b.Neg(b)
+	}
+	return b, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatScaled returns x (with places implied decimal places) rounded half up to precision places.
+func formatScaled(x *big.Int, places, precision int) string {
+	mag := new(big.Int).Abs(x)
+	if precision < places {
+		unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places-precision)), nil)
+		mag.Add(mag, new(big.Int).Rsh(unit, 1)) // unit is even, unit/2 rounds half up
+		mag.Quo(mag, unit)
+		places = precision
+	}
+	digits := mag.String()
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+	s := digits
+	if places > 0 {
+		s = digits[:len(digits)-places] + "." + digits[len(digits)-places:]
+	}
+	if precision > places {
+		if places == 0 {
+			s += "."
+		}
+		s += strings.Repeat("0", precision-places)
+	}
+	if x.Sign() < 0 && strings.Trim(digits, "0") != "" {
+		s = "-" + s
+	}
+	return s
+}
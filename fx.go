@@ -0,0 +1,207 @@
+package decimal4
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrFXRateNotFound = errors.New("decimal4: fx rate not found")
+	ErrFXRateInvalid  = errors.New("decimal4: fx rate must be > 0 and bid <= ask")
+)
+
+// FXSide selects which side of a quote is used for a conversion.
+type FXSide int
+
+const (
+	Mid FXSide = iota // (Bid + Ask) / 2
+	Bid               // rate at which base is sold for quote currency
+	Ask               // rate at which base is bought with quote currency
+)
+
+// FXRate is the price of 1 unit of Base in Quote currency, effective from Effective.
+type FXRate struct {
+	Base      string
+	Quote     string
+	Bid       Decimal6
+	Ask       Decimal6
+	Effective time.Time
+}
+
+// rat returns the side of this rate as an exact fraction.
+func (this FXRate) rat(side FXSide) *big.Rat {
+	switch side {
+	case Bid:
+		return big.NewRat(int64(this.Bid), 1000000)
+	case Ask:
+		return big.NewRat(int64(this.Ask), 1000000)
+	}
+	return new(big.Rat).SetFrac(new(big.Int).Add(big.NewInt(int64(this.Bid)), big.NewInt(int64(this.Ask))), big.NewInt(2000000))
+}
+
+// FXTable holds currency pair rates by effective date.
+// Inverse rates (quote to base) and cross rates through Pivot are derived exactly,
+// so a conversion is only rounded once, to the target currency's minor units.
+// An FXTable is safe for concurrent use.
+type FXTable struct {
+	Pivot string       // currency used for cross rates, "" = any currency with rates to both
+	Mode  RoundingMode // rounding of converted amounts
+
+	mu    sync.RWMutex
+	rates map[[2]string][]FXRate // sorted by Effective
+}
+
+// NewFXTable returns an empty FXTable using pivot for cross rates.
+func NewFXTable(pivot string) *FXTable {
+	return &FXTable{Pivot: pivot, rates: make(map[[2]string][]FXRate)}
+}
+
+// SetRate adds a rate with no spread (Bid = Ask = rate).
+func (this *FXTable) SetRate(base, quote string, rate Decimal6, effective time.Time) error {
+	return this.Set(FXRate{Base: base, Quote: quote, Bid: rate, Ask: rate, Effective: effective})
+}
+
+// Set adds rate, replacing any rate for the same pair and Effective time.
+func (this *FXTable) Set(rate FXRate) error {
+	if rate.Bid <= 0 || rate.Ask < rate.Bid || rate.Base == rate.Quote {
+		return ErrFXRateInvalid
+	}
+	this.mu.Lock()
+	defer this.mu.Unlock()
+	if this.rates == nil {
+		this.rates = make(map[[2]string][]FXRate)
+	}
+	key := [2]string{rate.Base, rate.Quote}
+	list := this.rates[key]
+	i := sort.Search(len(list), func(i int) bool { return !list[i].Effective.Before(rate.Effective) })
+	if i < len(list) && list[i].Effective.Equal(rate.Effective) {
+		list[i] = rate
+		return nil
+	}
+	list = append(list, FXRate{})
+	copy(list[i+1:], list[i:])
+	list[i] = rate
+	this.rates[key] = list
+	return nil
+}
+
+// Lookup returns the latest stored rate for base/quote effective on or before at.
+// Inverse and cross rates are not derived.
+func (this *FXTable) Lookup(base, quote string, at time.Time) (FXRate, error) {
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+	if rate, found := this.lookup(base, quote, at); found {
+		return rate, nil
+	}
+	return FXRate{}, ErrFXRateNotFound
+}
+
+func (this *FXTable) lookup(base, quote string, at time.Time) (FXRate, bool) {
+	list := this.rates[[2]string{base, quote}]
+	i := sort.Search(len(list), func(i int) bool { return list[i].Effective.After(at) })
+	if i == 0 {
+		return FXRate{}, false
+	}
+	return list[i-1], true
+}
+
+// pair returns the direct or inverse rate for from/to.
+// The inverse of Bid is 1 / Ask, the inverse of Ask is 1 / Bid.
+func (this *FXTable) pair(from, to string, at time.Time, side FXSide) (*big.Rat, bool) {
+	if rate, found := this.lookup(from, to, at); found {
+		return rate.rat(side), true
+	}
+	rate, found := this.lookup(to, from, at)
+	if !found {
+		return nil, false
+	}
+	switch side {
+	case Bid:
+		side = Ask
+	case Ask:
+		side = Bid
+	}
+	return new(big.Rat).Inv(rate.rat(side)), true
+}
+
+// rat returns the exact rate from/to, direct, inverse or through a pivot currency.
+func (this *FXTable) rat(from, to string, at time.Time, side FXSide) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+	this.mu.RLock()
+	defer this.mu.RUnlock()
+	if r, found := this.pair(from, to, at, side); found {
+		return r, nil
+	}
+	pivots := []string{this.Pivot}
+	if this.Pivot == "" {
+		pivots = this.currencies()
+	}
+	for _, pivot := range pivots {
+		if pivot == from || pivot == to {
+			continue
+		}
+		r1, found1 := this.pair(from, pivot, at, side)
+		r2, found2 := this.pair(pivot, to, at, side)
+		if found1 && found2 {
+			return r1.Mul(r1, r2), nil
+		}
+	}
+	return nil, ErrFXRateNotFound
+}
+
+// currencies returns all currencies in the table, sorted.
+func (this *FXTable) currencies() []string {
+	seen := make(map[string]bool)
+	var list []string
+	for key := range this.rates {
+		for _, c := range key {
+			if !seen[c] {
+				seen[c] = true
+				list = append(list, c)
+			}
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Rate returns the rate from/to at time at, rounded to 6 places (RoundHalfUp).
+// Use Convert for amounts, it does not round the rate.
+// Returns ErrOverflow if the derived rate does not fit in Decimal6.
+func (this *FXTable) Rate(from, to string, at time.Time, side FXSide) (Decimal6, error) {
+	r, err := this.rat(from, to, at, side)
+	if err != nil {
+		return 0, err
+	}
+	// rate in Decimal6 units, Places 4 rounds to whole units
+	x := new(big.Rat).Mul(r, big.NewRat(1000000, 1))
+	rate, ok := Rounding{Places: 4, Mode: RoundHalfUp}.ratRound(x)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return Decimal6(rate), nil
+}
+
+// Convert returns amount in currency from converted to currency to, using the rate at time at.
+// The result is rounded to CurrencyPlaces(to) (at most 4) using this.Mode.
+func (this *FXTable) Convert(amount Decimal4, from, to string, at time.Time, side FXSide) (Decimal4, error) {
+	r, err := this.rat(from, to, at, side)
+	if err != nil {
+		return 0, err
+	}
+	places := CurrencyPlaces(to)
+	if places > 4 {
+		places = 4
+	}
+	x := r.Mul(r, big.NewRat(int64(amount), 1))
+	result, ok := Rounding{Places: places, Mode: this.Mode}.ratRound(x)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return Decimal4(result), nil
+}
@@ -0,0 +1,75 @@
+package decimal4
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFXTable(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	fx := NewFXTable("USD")
+	fx.SetRate("GBP", "USD", NewDecimal6(1.25), jan)
+	fx.SetRate("GBP", "USD", NewDecimal6(1.3), feb)
+	fx.SetRate("USD", "JPY", NewDecimal6(150.123456), jan)
+	fx.Set(FXRate{Base: "EUR", Quote: "USD", Bid: NewDecimal6(1.08), Ask: NewDecimal6(1.1), Effective: jan})
+	if err := fx.SetRate("EUR", "USD", 0, jan); err != ErrFXRateInvalid {
+		t.Error("expected ErrFXRateInvalid, got", err)
+	}
+
+	type input struct {
+		amount float64
+		from   string
+		to     string
+		at     time.Time
+		side   FXSide
+		result float64
+	}
+	data := []input{
+		{100, "GBP", "USD", jan, Mid, 125},
+		{100, "GBP", "USD", feb.Add(time.Hour), Mid, 130},
+		{100, "GBP", "USD", feb.Add(-time.Hour), Mid, 125},
+		{100, "USD", "GBP", jan, Mid, 80},
+		{100, "USD", "GBP", feb, Mid, 76.92},
+		{100, "GBP", "JPY", jan, Mid, 18765},       // 187.65432, JPY has 0 places
+		{100, "JPY", "GBP", jan, Mid, .53},         // 100 / 187.65432
+		{1000000, "JPY", "USD", jan, Mid, 6661.18}, // inverse not rounded to 6 places first
+		{100, "EUR", "USD", jan, Bid, 108},
+		{100, "EUR", "USD", jan, Ask, 110},
+		{100, "EUR", "USD", jan, Mid, 109},
+		{108, "USD", "EUR", jan, Ask, 100}, // 1 / Bid
+		{110, "USD", "EUR", jan, Bid, 100}, // 1 / Ask
+		{100, "EUR", "EUR", jan, Mid, 100},
+	}
+	for i, v := range data {
+		result, err := fx.Convert(New(v.amount), v.from, v.to, v.at, v.side)
+		if err != nil || result != New(v.result) {
+			t.Errorf("data[%d]: result should be %f, but is %s, %v", i, v.result, result, err)
+		}
+	}
+
+	if _, err := fx.Convert(New(1), "GBP", "USD", jan.Add(-time.Hour), Mid); err != ErrFXRateNotFound {
+		t.Error("expected ErrFXRateNotFound before first effective date, got", err)
+	}
+	if rate, _ := fx.Rate("JPY", "USD", jan, Mid); rate != NewDecimal6(.006661) {
+		t.Errorf("Rate should be .006661, but is %s", rate)
+	}
+	if rate, _ := fx.Lookup("GBP", "USD", feb); rate.Bid != NewDecimal6(1.3) {
+		t.Errorf("Lookup should be 1.3, but is %s", rate.Bid)
+	}
+
+	table := NewFXTable("")
+	table.SetRate("GBP", "EUR", NewDecimal6(1.2), jan)
+	table.SetRate("CHF", "EUR", NewDecimal6(1.05), jan)
+	if result, _ := table.Convert(New(105), "CHF", "GBP", jan, Mid); result != New(91.88) {
+		t.Errorf("cross without pivot should be 91.88, but is %s", result)
+	}
+	table.SetRate("XXX", "EUR", Decimal6(1), jan) // .000001
+	table.SetRate("YYY", "EUR", NewDecimal6(10000000), jan)
+	if _, err := table.Rate("YYY", "XXX", jan, Mid); err != ErrOverflow { // 10 trillion does not fit
+		t.Error("Rate expected ErrOverflow, got", err)
+	}
+	if _, err := table.Convert(MaxDecimal4, "YYY", "XXX", jan, Mid); err != ErrOverflow {
+		t.Error("Convert expected ErrOverflow, got", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Package generic provides algorithms that work for Decimal4, Decimal6 and the Fixed types
+// of package decimal4. Functions are checked and return decimal4.ErrOverflow,
+// decimal4.ErrDivisionByZero or decimal4.ErrEmpty instead of panicking.
+package generic
+
+import (
+	"log"
+
+	"github.com/txjmp/decimal4"
+)
+
+// pow10 returns 10^n for 0 <= n <= 18.
+func pow10(n int) int64 {
+	p := int64(1)
+	for ; n > 0; n-- {
+		p *= 10
+	}
+	return p
+}
+
+// Add returns a + b.
+func Add[T decimal4.Decimal](a, b T) (T, error) {
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return 0, decimal4.ErrOverflow
+	}
+	return c, nil
+}
+
+// Sub returns a - b.
+func Sub[T decimal4.Decimal](a, b T) (T, error) {
+	c := a - b
+	if (b > 0 && c > a) || (b < 0 && c < a) {
+		return 0, decimal4.ErrOverflow
+	}
+	return c, nil
+}
+
+// Mul returns a * b rounded to the scale of T using mode.
+func Mul[T decimal4.Decimal](a, b T, mode decimal4.RoundingMode) (T, error) {
+	c, err := mode.MulDiv(int64(a), int64(b), pow10(a.Places()))
+	return T(c), err
+}
+
+// Div returns a / b rounded to the scale of T using mode.
+func Div[T decimal4.Decimal](a, b T, mode decimal4.RoundingMode) (T, error) {
+	c, err := mode.MulDiv(int64(a), pow10(a.Places()), int64(b))
+	return T(c), err
+}
+
+// MulInt returns a * n.
+func MulInt[T decimal4.Decimal](a T, n int64) (T, error) {
+	c, err := decimal4.RoundDown.MulDiv(int64(a), n, 1)
+	return T(c), err
+}
+
+// DivInt returns a / n rounded using mode.
+func DivInt[T decimal4.Decimal](a T, n int64, mode decimal4.RoundingMode) (T, error) {
+	c, err := mode.MulDiv(int64(a), 1, n)
+	return T(c), err
+}
+
+// Round returns x rounded to places decimal places using mode.
+// Panics if places is not 0 to x.Places().
+func Round[T decimal4.Decimal](x T, places int, mode decimal4.RoundingMode) (T, error) {
+	if places < 0 || places > x.Places() {
+		log.Panic("generic Round invalid places=", places)
+	}
+	unit := pow10(x.Places() - places)
+	q, err := mode.MulDiv(int64(x), 1, unit)
+	if err != nil {
+		return 0, err
+	}
+	c, err := mode.MulDiv(q, unit, 1)
+	return T(c), err
+}
+
+// Sum returns the total of values, 0 if values is empty.
+func Sum[T decimal4.Decimal](values []T) (T, error) {
+	return decimal4.SumChecked(values)
+}
+
+// Mean returns the average of values rounded using mode.
+// It does not overflow when the total of values would.
+func Mean[T decimal4.Decimal](values []T, mode decimal4.RoundingMode) (T, error) {
+	return decimal4.Mean(values, mode)
+}
+
+// Min returns the smallest of values.
+func Min[T decimal4.Decimal](values []T) (T, error) {
+	return decimal4.Min(values)
+}
+
+// Max returns the largest of values.
+func Max[T decimal4.Decimal](values []T) (T, error) {
+	return decimal4.Max(values)
+}
+
+// Allocate splits amount into shares proportional to weights, each a multiple of
+// 10^-places (places 0 to amount.Places(), panics otherwise), see decimal4.Allocate.
+func Allocate[T decimal4.Decimal](amount T, weights []T, places int) ([]T, error) {
+	return decimal4.Allocate(amount, weights, places)
+}
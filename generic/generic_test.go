@@ -0,0 +1,138 @@
+package generic
+
+import (
+	"testing"
+
+	"github.com/txjmp/decimal4"
+)
+
+func TestArithmetic(t *testing.T) {
+	a, b := decimal4.New(10), decimal4.New(3)
+	if x, _ := Add(a, b); x != decimal4.New(13) {
+		t.Error("Add should be 13, but is", x)
+	}
+	if x, _ := Sub(a, b); x != decimal4.New(7) {
+		t.Error("Sub should be 7, but is", x)
+	}
+	if x, _ := Mul(a, b, decimal4.RoundHalfUp); x != decimal4.New(30) {
+		t.Error("Mul should be 30, but is", x)
+	}
+	if x, _ := Div(a, b, decimal4.RoundHalfUp); x != decimal4.New(3.3333) {
+		t.Error("Div should be 3.3333, but is", x)
+	}
+	if x, _ := Div(a, b, decimal4.RoundUp); x != decimal4.New(3.3334) {
+		t.Error("Div RoundUp should be 3.3334, but is", x)
+	}
+	if x, _ := DivInt(decimal4.Decimal2(1001), 2, decimal4.RoundHalfEven); x != 500 {
+		t.Error("DivInt should be 5.00, but is", x)
+	}
+	if x, _ := MulInt(decimal4.Decimal6(1500000), 3); x != 4500000 {
+		t.Error("MulInt should be 4.5, but is", x)
+	}
+	if x, _ := Round(decimal4.New(2.345), 2, decimal4.RoundHalfEven); x != decimal4.New(2.34) {
+		t.Error("Round should be 2.34, but is", x)
+	}
+
+	max := decimal4.Decimal4(1<<63 - 1)
+	if _, err := Add(max, 1); err != decimal4.ErrOverflow {
+		t.Error("Add expected ErrOverflow, got", err)
+	}
+	if _, err := Sub(-max, 2); err != decimal4.ErrOverflow {
+		t.Error("Sub expected ErrOverflow, got", err)
+	}
+	if _, err := Mul(max, b, decimal4.RoundHalfUp); err != decimal4.ErrOverflow {
+		t.Error("Mul expected ErrOverflow, got", err)
+	}
+	if _, err := Div(a, 0, decimal4.RoundHalfUp); err != decimal4.ErrDivisionByZero {
+		t.Error("Div expected ErrDivisionByZero, got", err)
+	}
+}
+
+func TestAggregates(t *testing.T) {
+	type input struct {
+		values    []float64
+		sum, mean float64
+		min, max  float64
+	}
+	data := []input{
+		{[]float64{1, 2, 3, 4}, 10, 2.5, 1, 4},
+		{[]float64{1, 1, 2}, 4, 1.3333, 1, 2},
+		{[]float64{-1, -1, -2}, -4, -1.3333, -2, -1},
+		{[]float64{.0001, .0001, .0002}, .0004, .0001, .0001, .0002},
+		{[]float64{-5.5}, -5.5, -5.5, -5.5, -5.5},
+	}
+	for i, v := range data {
+		values := make([]decimal4.Decimal4, len(v.values))
+		for j, x := range v.values {
+			values[j] = decimal4.New(x)
+		}
+		sum, _ := Sum(values)
+		mean, _ := Mean(values, decimal4.RoundHalfUp)
+		min, _ := Min(values)
+		max, _ := Max(values)
+		if sum != decimal4.New(v.sum) || mean != decimal4.New(v.mean) || min != decimal4.New(v.min) || max != decimal4.New(v.max) {
+			t.Errorf("data[%d]: got sum %s mean %s min %s max %s", i, sum, mean, min, max)
+		}
+	}
+
+	// mean of values whose sum overflows
+	large := decimal4.Decimal6(1<<63 - 1)
+	if mean, err := Mean([]decimal4.Decimal6{large, large - 2}, decimal4.RoundHalfUp); err != nil || mean != large-1 {
+		t.Error("Mean should be", large-1, "but is", mean, err)
+	}
+	if _, err := Sum([]decimal4.Decimal6{large, large}); err != decimal4.ErrOverflow {
+		t.Error("Sum expected ErrOverflow, got", err)
+	}
+	if _, err := Mean([]decimal4.Decimal2{}, decimal4.RoundHalfUp); err != decimal4.ErrEmpty {
+		t.Error("Mean expected ErrEmpty, got", err)
+	}
+	if _, err := Min([]decimal4.Decimal4(nil)); err != decimal4.ErrEmpty {
+		t.Error("Min expected ErrEmpty, got", err)
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	type input struct {
+		amount  int64
+		weights []int64
+		places  int
+		shares  []int64
+	}
+	// amounts in Decimal2 units
+	data := []input{
+		{10000, []int64{1, 1, 1}, 2, []int64{3334, 3333, 3333}},
+		{-10000, []int64{1, 1, 1}, 2, []int64{-3334, -3333, -3333}},
+		{1000, []int64{0, 0}, 2, []int64{500, 500}},
+		{5, []int64{30, 70}, 2, []int64{2, 3}},
+		{1000, []int64{1, 2}, 0, []int64{300, 700}},
+	}
+	for i, v := range data {
+		weights := make([]decimal4.Decimal2, len(v.weights))
+		for j, w := range v.weights {
+			weights[j] = decimal4.Decimal2(w)
+		}
+		shares, err := Allocate(decimal4.Decimal2(v.amount), weights, v.places)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j, s := range v.shares {
+			if shares[j] != decimal4.Decimal2(s) {
+				t.Errorf("data[%d]: share %d should be %d, but is %d", i, j, s, shares[j])
+			}
+		}
+	}
+
+	// agrees with decimal4.Allocate
+	amount := decimal4.New(99.999)
+	weights := []decimal4.Decimal4{decimal4.New(1), decimal4.New(2), decimal4.New(4)}
+	want, _ := decimal4.Allocate(amount, weights, 2)
+	got, _ := Allocate(amount, weights, 2)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("share %d should be %s, but is %s", i, want[i], got[i])
+		}
+	}
+	if _, err := Allocate(decimal4.Decimal2(100), []decimal4.Decimal2{1, -1}, 2); err != decimal4.ErrAllocateWeights {
+		t.Error("expected ErrAllocateWeights, got", err)
+	}
+}
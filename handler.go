@@ -0,0 +1,75 @@
+package decimal4
+
+import (
+	"fmt"
+	"log"
+	"sync/atomic"
+)
+
+// Handler receives events from the methods that do not return errors, such as Multiply.
+//
+// OnOverflow is called when a result does not fit. The DefaultHandler logs and panics (log.Panic)
+// with a message such as "Decimal4 Multiply Overflow, this=1.0000 x=2.0000".
+// A handler may panic with its own value, for example an error to recover higher up;
+// if OnOverflow returns, the method returns an unspecified value.
+//
+// OnInexact is called when MultiplyBig, MultiplyBig6 or DivideBig drop non-zero digits.
+// The DefaultHandler ignores it.
+//
+// op names the method, for example "Decimal4 Multiply", operands are its receiver and arguments,
+// followed for Divide, DivideBig and DivideInt by the intermediate value that overflowed.
+type Handler interface {
+	OnOverflow(op string, operands ...any)
+	OnInexact(op string, operands ...any)
+}
+
+type defaultHandler struct{}
+
+// operandNames label the operands in the DefaultHandler message.
+var operandNames = []string{", this=", " x=", " a="}
+
+func (defaultHandler) OnOverflow(op string, operands ...any) {
+	msg := op + " Overflow"
+	for i, v := range operands {
+		name := fmt.Sprint(" operand", i, "=")
+		if i < len(operandNames) {
+			name = operandNames[i]
+		}
+		msg += name + fmt.Sprint(v)
+	}
+	log.Panic(msg)
+}
+
+func (defaultHandler) OnInexact(op string, operands ...any) {}
+
+// DefaultHandler panics on overflow and ignores inexact results.
+var DefaultHandler Handler = defaultHandler{}
+
+type handlerBox struct{ h Handler }
+
+var handler atomic.Value // handlerBox
+
+// SetHandler installs h for all goroutines and returns the previous handler.
+// nil restores DefaultHandler. It is safe to call while other goroutines calculate.
+func SetHandler(h Handler) Handler {
+	if h == nil {
+		h = DefaultHandler
+	}
+	return handler.Swap(handlerBox{h}).(handlerBox).h
+}
+
+func currentHandler() Handler {
+	return handler.Load().(handlerBox).h
+}
+
+func init() {
+	handler.Store(handlerBox{DefaultHandler})
+}
+
+func overflow(op string, operands ...any) {
+	currentHandler().OnOverflow(op, operands...)
+}
+
+func inexact(op string, operands ...any) {
+	currentHandler().OnInexact(op, operands...)
+}
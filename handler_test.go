@@ -0,0 +1,121 @@
+package decimal4
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type countingHandler struct {
+	overflows, inexacts atomic.Int64
+	lastOp              atomic.Value
+}
+
+func (this *countingHandler) OnOverflow(op string, operands ...any) {
+	this.overflows.Add(1)
+	this.lastOp.Store(op)
+}
+
+func (this *countingHandler) OnInexact(op string, operands ...any) {
+	this.inexacts.Add(1)
+	this.lastOp.Store(op)
+}
+
+type errorHandler struct{}
+
+type overflowError struct{ op string }
+
+func (this overflowError) Error() string { return this.op + " overflow" }
+func (this overflowError) Unwrap() error { return ErrOverflow }
+
+func (errorHandler) OnOverflow(op string, operands ...any) { panic(overflowError{op}) }
+func (errorHandler) OnInexact(op string, operands ...any)  {}
+
+func TestHandler(t *testing.T) {
+	counter := new(countingHandler)
+	if prev := SetHandler(counter); prev != DefaultHandler {
+		t.Error("previous handler should be DefaultHandler")
+	}
+	defer SetHandler(nil)
+
+	MaxDecimal4.Multiply(New(2))
+	if counter.overflows.Load() != 1 || counter.lastOp.Load() != "Decimal4 Multiply" {
+		t.Error("OnOverflow not called for Multiply, op", counter.lastOp.Load())
+	}
+	NewWide(MaxDecimal4).Multiply(MaxDecimal4).Multiply(MaxDecimal4)
+	if counter.overflows.Load() != 2 || counter.lastOp.Load() != "Wide Multiply" {
+		t.Error("OnOverflow not called for Wide Multiply, op", counter.lastOp.Load())
+	}
+
+	New(1.2345).MultiplyBig6(NewDecimal6(2))
+	New(10).DivideBig(New(3))
+	New(1.23).MultiplyBig(New(2))
+	if counter.inexacts.Load() != 2 || counter.lastOp.Load() != "Decimal4 DivideBig" {
+		t.Error("OnInexact should be called twice, got", counter.inexacts.Load(), counter.lastOp.Load())
+	}
+
+	// convert overflow to an error
+	SetHandler(errorHandler{})
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = r.(error)
+			}
+		}()
+		New(100000000000).Divide(New(3))
+		return nil
+	}()
+	if !errors.Is(err, ErrOverflow) || err.Error() != "Decimal4 Divide overflow" {
+		t.Error("expected Decimal4 Divide overflow, got", err)
+	}
+
+	// default panics again, with the same message as before handlers
+	SetHandler(nil)
+	big := New(100000000000)
+	tests := []struct {
+		f        func()
+		expected string
+	}{
+		{func() { MaxDecimal4.MultiplyInt(2) }, fmt.Sprint("Decimal4 MultiplyInt Overflow, this=", MaxDecimal4, " x=", 2)},
+		{func() { MaxDecimal4.Multiply(New(2)) }, fmt.Sprint("Decimal4 Multiply Overflow, this=", MaxDecimal4, " x=", New(2))},
+		{func() { big.Divide(New(3)) }, fmt.Sprint("Decimal4 Divide Overflow, this=", big, " x=", New(3), " a=", big*100000)},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Errorf("DefaultHandler should panic with %q, got %v", test.expected, r)
+				}
+			}()
+			test.f()
+		}()
+	}
+}
+
+func TestSetHandlerConcurrent(t *testing.T) {
+	defer SetHandler(nil)
+	counter := new(countingHandler)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				SetHandler(counter)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				New(1.23).Multiply(New(2))
+				currentHandler().OnInexact("test")
+			}
+		}()
+	}
+	wg.Wait()
+	if currentHandler() != Handler(counter) {
+		t.Error("handler should be counter")
+	}
+}
@@ -0,0 +1,164 @@
+package decimal4
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// int128 is a two's complement 128-bit integer, used by Wide and TokenAmount.
+type int128 struct {
+	hi uint64 // sign in top bit
+	lo uint64
+}
+
+func int128From64(x int64) int128 {
+	if x < 0 {
+		return int128{math.MaxUint64, uint64(x)}
+	}
+	return int128{0, uint64(x)}
+}
+
+// int64 returns a as int64, ok is false if it does not fit.
+func (a int128) int64() (int64, bool) {
+	lo := int64(a.lo)
+	if (lo < 0 && a.hi != math.MaxUint64) || (lo >= 0 && a.hi != 0) {
+		return 0, false
+	}
+	return lo, true
+}
+
+func (a int128) sign() int {
+	if int64(a.hi) < 0 {
+		return -1
+	}
+	if a.hi == 0 && a.lo == 0 {
+		return 0
+	}
+	return 1
+}
+
+func (a int128) cmp(b int128) int {
+	switch {
+	case int64(a.hi) < int64(b.hi):
+		return -1
+	case int64(a.hi) > int64(b.hi):
+		return 1
+	case a.lo < b.lo:
+		return -1
+	case a.lo > b.lo:
+		return 1
+	}
+	return 0
+}
+
+// isMin reports whether a is the smallest int128, which has no positive counterpart.
+func (a int128) isMin() bool {
+	return a.hi == 1<<63 && a.lo == 0
+}
+
+// neg returns -a, wrapping for the smallest int128.
+func (a int128) neg() int128 {
+	lo, borrow := bits.Sub64(0, a.lo, 0)
+	hi, _ := bits.Sub64(0, a.hi, borrow)
+	return int128{hi, lo}
+}
+
+func (a int128) add(b int128) (int128, bool) {
+	lo, carry := bits.Add64(a.lo, b.lo, 0)
+	hi, _ := bits.Add64(a.hi, b.hi, carry)
+	result := int128{hi, lo}
+	if a.sign() < 0 == (b.sign() < 0) && result.sign() < 0 != (a.sign() < 0) {
+		return int128{}, false
+	}
+	return result, true
+}
+
+func (a int128) sub(b int128) (int128, bool) {
+	lo, borrow := bits.Sub64(a.lo, b.lo, 0)
+	hi, _ := bits.Sub64(a.hi, b.hi, borrow)
+	result := int128{hi, lo}
+	if a.sign() < 0 != (b.sign() < 0) && result.sign() < 0 != (a.sign() < 0) {
+		return int128{}, false
+	}
+	return result, true
+}
+
+// abs returns the magnitude of a and whether a is negative.
+func (a int128) abs() (hi, lo uint64, neg bool) {
+	if a.sign() < 0 {
+		n := a.neg() // smallest int128 negates to itself, which is the correct unsigned magnitude
+		return n.hi, n.lo, true
+	}
+	return a.hi, a.lo, false
+}
+
+// int128FromMagnitude returns hi, lo with sign neg, ok is false if it does not fit.
+func int128FromMagnitude(hi, lo uint64, neg bool) (int128, bool) {
+	if hi > 1<<63 || (hi == 1<<63 && (lo != 0 || !neg)) {
+		return int128{}, false
+	}
+	a := int128{hi, lo}
+	if neg {
+		a = a.neg()
+	}
+	return a, true
+}
+
+// mulDiv returns a * m / d, m and d > 0, rounded using mode.
+func (a int128) mulDiv(m, d uint64, mode RoundingMode) (int128, bool) {
+	hi, lo, neg := a.abs()
+	// 192-bit product w2:w1:w0
+	p0hi, w0 := bits.Mul64(lo, m)
+	p1hi, p1lo := bits.Mul64(hi, m)
+	w1, carry := bits.Add64(p0hi, p1lo, 0)
+	w2 := p1hi + carry
+	if w2 >= d {
+		return int128{}, false
+	}
+	q1, r := bits.Div64(w2, w1, d)
+	q0, r := bits.Div64(r, w0, d)
+	if mode.roundAway(neg, r != 0, compareHalf(r, d), q0&1 == 1) {
+		q0, carry = bits.Add64(q0, 1, 0)
+		q1 += carry
+	}
+	return int128FromMagnitude(q1, q0, neg)
+}
+
+// mulDivSigned returns a * m / d rounded using mode, d != 0.
+func (a int128) mulDivSigned(m, d int64, mode RoundingMode) (int128, bool) {
+	neg := (m < 0) != (d < 0)
+	if neg {
+		// round the magnitude of the negated result, so Floor and Ceiling stay correct
+		mode = mode.negated()
+	}
+	result, ok := a.mulDiv(absUint64(m), absUint64(d), mode)
+	if !ok || !neg {
+		return result, ok
+	}
+	if result.isMin() {
+		return int128{}, false
+	}
+	return result.neg(), true
+}
+
+func (a int128) big() *big.Int {
+	hi, lo, neg := a.abs()
+	b := new(big.Int).SetUint64(hi)
+	b.Lsh(b, 64)
+	b.Or(b, new(big.Int).SetUint64(lo))
+	if neg {
+		b.Neg(b)
+	}
+	return b
+}
+
+func int128FromBig(b *big.Int) (int128, bool) {
+	if b.BitLen() > 128 {
+		return int128{}, false
+	}
+	mag := new(big.Int).Abs(b)
+	lo := new(big.Int).And(mag, new(big.Int).SetUint64(math.MaxUint64)).Uint64()
+	hi := mag.Rsh(mag, 64).Uint64()
+	return int128FromMagnitude(hi, lo, b.Sign() < 0)
+}
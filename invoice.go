@@ -0,0 +1,358 @@
+package decimal4
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// ErrInvoiceTaxCode is returned by Invoice.Calculate when a line's TaxCode is not in TaxSets.
+var ErrInvoiceTaxCode = errors.New("decimal4: invoice line has unknown tax code")
+
+// InvoiceRounding is the default rounding for line extensions and discounts, used by an
+// Invoice without Rounding. Change it only during initialization, see TaxRounding.
+var InvoiceRounding = Rounding{Places: 2, Mode: RoundHalfUp}
+
+// LineItem is one line of an Invoice.
+// Quantity, UnitPrice, Discounts and TaxCode are inputs, remaining fields are set by Invoice.Calculate.
+// Amounts are encoded in JSON as decimal numbers, for example 56.2700 and 0.075000, see MarshalJSON.
+type LineItem struct {
+	Description string
+	Quantity    Decimal4
+	UnitPrice   Decimal4
+	Discounts   []Decimal6 // chained rates, .10 then .05 on the remainder
+	TaxCode     string     // key into Invoice.TaxSets, "" = not taxed
+
+	Extended    Decimal4 // Quantity * UnitPrice
+	Discount    Decimal4 // total of line Discounts
+	DocDiscount Decimal4 // share of invoice discount
+	Net         Decimal4 // amount excluding tax
+	Tax         Decimal4
+	Total       Decimal4 // Net + Tax
+	Taxes       []TaxAmount
+}
+
+// Invoice computes line items, discounts and taxes.
+// The invoice discount (DiscountRate of the discounted lines plus DiscountAmount)
+// is allocated back to the lines in proportion to their amounts. Credit lines, such as
+// returns with a negative Quantity, get no share of it, but their taxes are negative.
+// TaxSets with Inclusive true treat unit prices as including tax.
+type Invoice struct {
+	Lines          []LineItem
+	DiscountRate   Decimal6
+	DiscountAmount Decimal4
+	TaxSets        map[string]TaxSet // not encoded in JSON
+	TaxMethod      TaxMethod         // PerLine or OnTotal for each tax code
+	Rounding       *Rounding         // line extensions and discounts, nil = InvoiceRounding, not encoded in JSON
+
+	Subtotal Decimal4 // sum of Extended
+	Discount Decimal4 // line and invoice discounts
+	Net      Decimal4
+	Tax      Decimal4
+	Total    Decimal4
+	Taxes    []TaxAmount // by tax Code and Rate
+}
+
+// Calculate computes all line and invoice totals.
+// Line and invoice totals always sum exactly: Net + Tax == Total.
+// Returns ErrInvoiceTaxCode, or ErrOverflow if an amount or total does not fit.
+func (this *Invoice) Calculate() error {
+	codes := make([]string, 0, len(this.TaxSets))
+	for code := range this.TaxSets {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, line := range this.Lines {
+		if _, found := this.TaxSets[line.TaxCode]; line.TaxCode != "" && !found {
+			return ErrInvoiceTaxCode
+		}
+	}
+
+	// line extensions and chained discounts
+	amounts := make([]Decimal4, len(this.Lines))
+	var err error
+	rounding := InvoiceRounding
+	if this.Rounding != nil {
+		rounding = *this.Rounding
+	}
+	var discounted Decimal4
+	for i := range this.Lines {
+		line := &this.Lines[i]
+		ext, ok := rounding.mulDiv(int64(line.Quantity), int64(line.UnitPrice), 10000)
+		if !ok {
+			return ErrOverflow
+		}
+		line.Extended = ext
+		amount := ext
+		for _, rate := range line.Discounts {
+			d, ok := rounding.mulDiv(int64(amount), int64(rate), 1000000)
+			if !ok {
+				return ErrOverflow
+			}
+			if amount, err = amount.Sub(d); err != nil {
+				return err
+			}
+		}
+		if line.Discount, err = ext.Sub(amount); err != nil {
+			return err
+		}
+		amounts[i] = amount
+		if discounted, err = discounted.Add(amount); err != nil {
+			return err
+		}
+	}
+
+	// invoice discount allocated back to lines, credit lines (negative amounts) get no share
+	docDiscount, ok := rounding.mulDiv(int64(discounted), int64(this.DiscountRate), 1000000)
+	if !ok {
+		return ErrOverflow
+	}
+	if docDiscount, err = docDiscount.Add(this.DiscountAmount); err != nil {
+		return err
+	}
+	for i := range this.Lines {
+		this.Lines[i].DocDiscount = 0
+	}
+	if docDiscount != 0 {
+		weights := make([]Decimal4, len(amounts))
+		for i, amount := range amounts {
+			if amount > 0 {
+				weights[i] = amount
+			}
+		}
+		shares, err := Allocate(docDiscount, weights, rounding.Places)
+		if err != nil {
+			return err
+		}
+		for i := range this.Lines {
+			this.Lines[i].DocDiscount = shares[i]
+			if amounts[i], err = amounts[i].Sub(shares[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	// taxes
+	for i := range this.Lines {
+		line := &this.Lines[i]
+		line.Net, line.Tax, line.Total, line.Taxes = amounts[i], 0, amounts[i], nil
+	}
+	for _, code := range codes {
+		set := this.TaxSets[code]
+		var lines []int
+		for i, line := range this.Lines {
+			if line.TaxCode == code {
+				lines = append(lines, i)
+			}
+		}
+		if this.TaxMethod == PerLine {
+			for _, i := range lines {
+				this.Lines[i].setTax(set.Apply(amounts[i]))
+			}
+			continue
+		}
+		if err := this.taxOnTotal(set, lines, amounts, rounding.Places); err != nil {
+			return err
+		}
+	}
+
+	// invoice totals
+	this.Subtotal, this.Discount, this.Net, this.Tax, this.Total, this.Taxes = 0, 0, 0, 0, 0, nil
+	totals := []*Decimal4{&this.Subtotal, &this.Discount, &this.Discount, &this.Net, &this.Tax, &this.Total}
+	for _, line := range this.Lines {
+		for j, v := range []Decimal4{line.Extended, line.Discount, line.DocDiscount, line.Net, line.Tax, line.Total} {
+			if *totals[j], err = totals[j].Add(v); err != nil {
+				return err
+			}
+		}
+		for _, v := range line.Taxes {
+			if err = this.addTax(v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// taxOnTotal applies set once to the total of lines, then allocates each tax back to the lines,
+// tax bases to places and tax amounts to the places of the set's rounding.
+func (this *Invoice) taxOnTotal(set TaxSet, lines []int, amounts []Decimal4, places int) error {
+	var total Decimal4
+	var err error
+	weights := make([]Decimal4, len(lines))
+	for j, i := range lines {
+		weights[j] = amounts[i]
+		if total, err = total.Add(amounts[i]); err != nil {
+			return err
+		}
+	}
+	result := set.Apply(total)
+	results := make([]TaxResult, len(lines))
+	for j, i := range lines {
+		results[j] = TaxResult{Net: amounts[i], Gross: amounts[i], Taxes: make([]TaxAmount, len(result.Taxes))}
+	}
+	for k, tax := range result.Taxes {
+		bases, err := allocateSigned(tax.Base, weights, places)
+		if err != nil {
+			return err
+		}
+		taxes, err := allocateSigned(tax.Amount, weights, set.rounding().Places)
+		if err != nil {
+			return err
+		}
+		for j := range lines {
+			results[j].Taxes[k] = TaxAmount{Code: tax.Code, Rate: tax.Rate, Base: bases[j], Amount: taxes[j]}
+			if set.Inclusive {
+				results[j].Net -= taxes[j]
+			} else {
+				results[j].Gross += taxes[j]
+			}
+		}
+	}
+	for j, i := range lines {
+		this.Lines[i].setTax(results[j])
+	}
+	return nil
+}
+
+func (this *LineItem) setTax(r TaxResult) {
+	this.Net = r.Net
+	this.Tax = r.Tax()
+	this.Total = r.Gross
+	this.Taxes = r.Taxes
+}
+
+// addTax adds v to the invoice tax breakdown, combining taxes with the same Code and Rate.
+// Returns ErrOverflow if a combined amount does not fit.
+func (this *Invoice) addTax(v TaxAmount) error {
+	for i, tax := range this.Taxes {
+		if tax.Code == v.Code && tax.Rate == v.Rate {
+			base, err := tax.Base.Add(v.Base)
+			if err != nil {
+				return err
+			}
+			amount, err := tax.Amount.Add(v.Amount)
+			if err != nil {
+				return err
+			}
+			this.Taxes[i].Base, this.Taxes[i].Amount = base, amount
+			return nil
+		}
+	}
+	this.Taxes = append(this.Taxes, v)
+	return nil
+}
+
+// Invoice JSON uses Fixed fields, so amounts are decimal numbers
+// while Decimal4 and Decimal6 keep encoding their stored values.
+
+type taxAmountJSON struct {
+	Code   string        `json:"code"`
+	Rate   Fixed[Scale6] `json:"rate"`
+	Base   Fixed[Scale4] `json:"base"`
+	Amount Fixed[Scale4] `json:"amount"`
+}
+
+type lineItemJSON struct {
+	Description string          `json:"description,omitempty"`
+	Quantity    Fixed[Scale4]   `json:"quantity"`
+	UnitPrice   Fixed[Scale4]   `json:"unitPrice"`
+	Discounts   []Fixed[Scale6] `json:"discounts,omitempty"`
+	TaxCode     string          `json:"taxCode,omitempty"`
+	Extended    Fixed[Scale4]   `json:"extended"`
+	Discount    Fixed[Scale4]   `json:"discount"`
+	DocDiscount Fixed[Scale4]   `json:"docDiscount"`
+	Net         Fixed[Scale4]   `json:"net"`
+	Tax         Fixed[Scale4]   `json:"tax"`
+	Total       Fixed[Scale4]   `json:"total"`
+	Taxes       []taxAmountJSON `json:"taxes,omitempty"`
+}
+
+type invoiceJSON struct {
+	Lines          []LineItem      `json:"lines"`
+	DiscountRate   Fixed[Scale6]   `json:"discountRate,omitempty"`
+	DiscountAmount Fixed[Scale4]   `json:"discountAmount,omitempty"`
+	TaxMethod      TaxMethod       `json:"taxMethod"`
+	Subtotal       Fixed[Scale4]   `json:"subtotal"`
+	Discount       Fixed[Scale4]   `json:"discount"`
+	Net            Fixed[Scale4]   `json:"net"`
+	Tax            Fixed[Scale4]   `json:"tax"`
+	Total          Fixed[Scale4]   `json:"total"`
+	Taxes          []taxAmountJSON `json:"taxes,omitempty"`
+}
+
+func taxesToJSON(taxes []TaxAmount) []taxAmountJSON {
+	if taxes == nil {
+		return nil
+	}
+	list := make([]taxAmountJSON, len(taxes))
+	for i, v := range taxes {
+		list[i] = taxAmountJSON{v.Code, Fixed[Scale6](v.Rate), Fixed[Scale4](v.Base), Fixed[Scale4](v.Amount)}
+	}
+	return list
+}
+
+func taxesFromJSON(list []taxAmountJSON) []TaxAmount {
+	if list == nil {
+		return nil
+	}
+	taxes := make([]TaxAmount, len(list))
+	for i, v := range list {
+		taxes[i] = TaxAmount{v.Code, Decimal6(v.Rate), Decimal4(v.Base), Decimal4(v.Amount)}
+	}
+	return taxes
+}
+
+// MarshalJSON encodes the line with amounts as decimal numbers, for example "total":56.2700.
+func (this LineItem) MarshalJSON() ([]byte, error) {
+	v := lineItemJSON{
+		Description: this.Description, Quantity: Fixed[Scale4](this.Quantity), UnitPrice: Fixed[Scale4](this.UnitPrice),
+		TaxCode: this.TaxCode, Extended: Fixed[Scale4](this.Extended), Discount: Fixed[Scale4](this.Discount),
+		DocDiscount: Fixed[Scale4](this.DocDiscount), Net: Fixed[Scale4](this.Net), Tax: Fixed[Scale4](this.Tax),
+		Total: Fixed[Scale4](this.Total), Taxes: taxesToJSON(this.Taxes),
+	}
+	for _, rate := range this.Discounts {
+		v.Discounts = append(v.Discounts, Fixed[Scale6](rate))
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON sets the line from JSON numbers or strings, for example 56.27 or "56.27".
+func (this *LineItem) UnmarshalJSON(data []byte) error {
+	var v lineItemJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*this = LineItem{
+		Description: v.Description, Quantity: Decimal4(v.Quantity), UnitPrice: Decimal4(v.UnitPrice),
+		TaxCode: v.TaxCode, Extended: Decimal4(v.Extended), Discount: Decimal4(v.Discount),
+		DocDiscount: Decimal4(v.DocDiscount), Net: Decimal4(v.Net), Tax: Decimal4(v.Tax),
+		Total: Decimal4(v.Total), Taxes: taxesFromJSON(v.Taxes),
+	}
+	for _, rate := range v.Discounts {
+		this.Discounts = append(this.Discounts, Decimal6(rate))
+	}
+	return nil
+}
+
+// MarshalJSON encodes the invoice with amounts as decimal numbers. TaxSets are not encoded.
+func (this Invoice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(invoiceJSON{
+		Lines: this.Lines, DiscountRate: Fixed[Scale6](this.DiscountRate), DiscountAmount: Fixed[Scale4](this.DiscountAmount),
+		TaxMethod: this.TaxMethod, Subtotal: Fixed[Scale4](this.Subtotal), Discount: Fixed[Scale4](this.Discount),
+		Net: Fixed[Scale4](this.Net), Tax: Fixed[Scale4](this.Tax), Total: Fixed[Scale4](this.Total),
+		Taxes: taxesToJSON(this.Taxes),
+	})
+}
+
+// UnmarshalJSON sets the invoice from JSON, keeping TaxSets.
+func (this *Invoice) UnmarshalJSON(data []byte) error {
+	var v invoiceJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	this.Lines, this.DiscountRate, this.DiscountAmount, this.TaxMethod = v.Lines, Decimal6(v.DiscountRate), Decimal4(v.DiscountAmount), v.TaxMethod
+	this.Subtotal, this.Discount, this.Net, this.Tax, this.Total = Decimal4(v.Subtotal), Decimal4(v.Discount), Decimal4(v.Net), Decimal4(v.Tax), Decimal4(v.Total)
+	this.Taxes = taxesFromJSON(v.Taxes)
+	return nil
+}
@@ -0,0 +1,162 @@
+package decimal4
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestInvoice(t *testing.T) {
+	inv := Invoice{
+		Lines: []LineItem{
+			{Quantity: New(3), UnitPrice: New(19.99), Discounts: []Decimal6{NewDecimal6(.1), NewDecimal6(.05)}, TaxCode: "STD"},
+			{Quantity: New(1.5), UnitPrice: New(10.005)},
+		},
+		DiscountRate: NewDecimal6(.02),
+		TaxSets: map[string]TaxSet{
+			"STD": {Taxes: []Tax{{Code: "GST", Rate: NewDecimal6(.05)}, {Code: "PST", Rate: NewDecimal6(.07)}}},
+		},
+	}
+	if err := inv.Calculate(); err != nil {
+		t.Fatal(err)
+	}
+	type expect struct {
+		name     string
+		value    Decimal4
+		expected float64
+	}
+	line := inv.Lines[0]
+	data := []expect{
+		{"line Extended", line.Extended, 59.97},
+		{"line Discount", line.Discount, 8.7},
+		{"line DocDiscount", line.DocDiscount, 1.03},
+		{"line Net", line.Net, 50.24},
+		{"line Tax", line.Tax, 6.03},
+		{"line Total", line.Total, 56.27},
+		{"line2 Extended", inv.Lines[1].Extended, 15.01},
+		{"line2 Total", inv.Lines[1].Total, 14.71},
+		{"Subtotal", inv.Subtotal, 74.98},
+		{"Discount", inv.Discount, 10.03},
+		{"Net", inv.Net, 64.95},
+		{"Tax", inv.Tax, 6.03},
+		{"Total", inv.Total, 70.98},
+		{"GST", inv.Taxes[0].Amount, 2.51},
+		{"PST", inv.Taxes[1].Amount, 3.52},
+	}
+	for _, v := range data {
+		if v.value != New(v.expected) {
+			t.Errorf("%s should be %f, but is %s", v.name, v.expected, v.value)
+		}
+	}
+
+	encoded, err := json.Marshal(inv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`"total":70.9800`, `"unitPrice":19.9900`, `"discounts":[0.100000,0.050000]`, `"discountRate":0.020000`} {
+		if !strings.Contains(string(encoded), s) {
+			t.Errorf("json should have %s, but is %s", s, encoded)
+		}
+	}
+	var decoded Invoice
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Total != inv.Total || decoded.Lines[0].Taxes[1] != inv.Lines[0].Taxes[1] || decoded.Lines[0].Discounts[1] != NewDecimal6(.05) {
+		t.Errorf("json round trip failed: %s", encoded)
+	}
+}
+
+func TestInvoiceTaxOnTotal(t *testing.T) {
+	inv := Invoice{
+		Lines: []LineItem{
+			{Quantity: New(1), UnitPrice: New(.05), TaxCode: "VAT"},
+			{Quantity: New(1), UnitPrice: New(.05), TaxCode: "VAT"},
+			{Quantity: New(1), UnitPrice: New(.05), TaxCode: "VAT"},
+		},
+		TaxSets:   map[string]TaxSet{"VAT": {Taxes: []Tax{{Code: "VAT", Rate: NewDecimal6(.1)}}}},
+		TaxMethod: OnTotal,
+	}
+	if err := inv.Calculate(); err != nil {
+		t.Fatal(err)
+	}
+	if inv.Tax != New(.02) || inv.Total != New(.17) || inv.Lines[0].Tax+inv.Lines[1].Tax+inv.Lines[2].Tax != inv.Tax {
+		t.Errorf("OnTotal tax should be .02, but is %s, total %s", inv.Tax, inv.Total)
+	}
+	inv.TaxMethod = PerLine
+	inv.Calculate()
+	if inv.Tax != New(.03) {
+		t.Errorf("PerLine tax should be .03, but is %s", inv.Tax)
+	}
+
+	inv.Lines[0].TaxCode = "XXX"
+	if err := inv.Calculate(); err != ErrInvoiceTaxCode {
+		t.Error("expected ErrInvoiceTaxCode, got", err)
+	}
+}
+
+func TestInvoiceCreditLine(t *testing.T) {
+	inv := Invoice{
+		Lines: []LineItem{
+			{Quantity: New(2), UnitPrice: New(10), TaxCode: "VAT"},
+			{Quantity: New(-1), UnitPrice: New(10), TaxCode: "VAT"},
+		},
+		TaxSets: map[string]TaxSet{"VAT": {Taxes: []Tax{{Code: "VAT", Rate: NewDecimal6(.1)}}}},
+	}
+	if err := inv.Calculate(); err != nil {
+		t.Fatal(err)
+	}
+	if inv.Net != New(10) || inv.Tax != New(1) || inv.Lines[1].Tax != New(-1) {
+		t.Errorf("net and tax should be 10 and 1, but are %s %s", inv.Net, inv.Tax)
+	}
+	inv.DiscountRate = NewDecimal6(.1) // 1.00 on net 10.00, all on the debit line
+	for _, method := range []TaxMethod{PerLine, OnTotal} {
+		inv.TaxMethod = method
+		if err := inv.Calculate(); err != nil {
+			t.Fatal(err)
+		}
+		if inv.Lines[0].DocDiscount != New(1) || inv.Lines[1].DocDiscount != 0 || inv.Net != New(9) {
+			t.Errorf("discount should be 1 on the debit line, but is %s %s", inv.Lines[0].DocDiscount, inv.Lines[1].DocDiscount)
+		}
+		if inv.Tax != New(.9) || inv.Lines[0].Tax != New(1.9) || inv.Lines[1].Tax != New(-1) || inv.Total != New(9.9) {
+			t.Errorf("method %d: tax should be 1.90 and -1.00, but is %s %s", method, inv.Lines[0].Tax, inv.Lines[1].Tax)
+		}
+	}
+}
+
+func TestInvoiceOverflow(t *testing.T) {
+	half := MaxDecimal4/2 + 100
+	data := []Invoice{
+		{Lines: []LineItem{{Quantity: MaxDecimal4, UnitPrice: New(2)}}},                                                // extension
+		{Lines: []LineItem{{Quantity: New(1), UnitPrice: half}, {Quantity: New(1), UnitPrice: half}}},                  // lines sum
+		{Lines: []LineItem{{Quantity: New(1), UnitPrice: MaxDecimal4}}, DiscountAmount: New(-1)},                       // discount added to net
+		{Lines: []LineItem{{Quantity: New(1), UnitPrice: half}}, DiscountRate: NewDecimal6(-1), DiscountAmount: -half}, // invoice discount
+		{Lines: []LineItem{{Quantity: New(1), UnitPrice: New(-1e14), Discounts: []Decimal6{NewDecimal6(-9)}}}},         // line discount
+	}
+	for i, inv := range data {
+		if err := inv.Calculate(); err != ErrOverflow {
+			t.Errorf("data[%d]: expected ErrOverflow, got %v", i, err)
+		}
+	}
+}
+
+func TestInvoiceRounding(t *testing.T) {
+	four := Rounding{Places: 4, Mode: RoundHalfUp}
+	inv := Invoice{
+		Lines:   []LineItem{{Quantity: New(3), UnitPrice: New(1.2345), TaxCode: "VAT"}},
+		TaxSets: map[string]TaxSet{"VAT": {Taxes: []Tax{{Code: "VAT", Rate: NewDecimal6(.1)}}, Rounding: &four}},
+	}
+	for _, method := range []TaxMethod{PerLine, OnTotal} {
+		inv.TaxMethod = method
+		inv.Calculate()
+		if inv.Lines[0].Extended != New(3.7) || inv.Tax != New(.37) {
+			t.Errorf("method %d: default extension and tax should be 3.70 and .37, but are %s %s", method, inv.Lines[0].Extended, inv.Tax)
+		}
+		inv.Rounding = &four
+		inv.Calculate()
+		if inv.Lines[0].Extended != New(3.7035) || inv.Tax != New(.3704) {
+			t.Errorf("method %d: extension and tax should be 3.7035 and .3704, but are %s %s", method, inv.Lines[0].Extended, inv.Tax)
+		}
+		inv.Rounding = nil
+	}
+}
@@ -0,0 +1,70 @@
+package decimal4
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Limits of the methods that panic on overflow, as Decimal4 values.
+const (
+	MultiplyMax     Decimal4 = (math.MaxInt64 - 5000) / 10000     // Multiply: |this * x| <= about 92 billion
+	Multiply6Max    Decimal4 = (math.MaxInt64 - 500000) / 1000000 // Multiply6: |this * x| <= about 922 million
+	MultiplyBigMax  Decimal4 = (math.MaxInt64 - 50) / 100         // MultiplyBig: |this * x| <= about 9.2 trillion
+	MultiplyBig6Max Decimal4 = (math.MaxInt64 - 5000) / 10000     // MultiplyBig6: |this * x| <= about 92 billion
+	DivideMax       Decimal4 = math.MaxInt64 / 100000             // Divide: |this| <= about 9.2 billion
+	DivideBigMax    Decimal4 = math.MaxInt64 / 1000               // DivideBig: |this| <= about 922 billion
+	DivideIntMax    Decimal4 = math.MaxInt64 / 10                 // DivideInt: |this| <= about 92 trillion
+)
+
+// productFits reports whether |a * b| <= max.
+func productFits(a, b int64, max uint64) bool {
+	hi, lo := bits.Mul64(absUint64(a), absUint64(b))
+	return hi == 0 && lo <= max
+}
+
+// CanMultiply reports whether a.Multiply(b) will not overflow.
+func CanMultiply(a, b Decimal4) bool {
+	return productFits(int64(a), int64(b), math.MaxInt64-5000)
+}
+
+// CanMultiply6 reports whether a.Multiply6(b) will not overflow.
+func CanMultiply6(a Decimal4, b Decimal6) bool {
+	return productFits(int64(a), int64(b), math.MaxInt64-500000)
+}
+
+// CanMultiplyBig reports whether a.MultiplyBig(b) will not overflow.
+func CanMultiplyBig(a, b Decimal4) bool {
+	if Abs(a) <= Abs(b) { // same choice as MultiplyBig: the larger operand loses 2 places
+		a, b = b, a
+	}
+	return productFits(int64(a/100), int64(b), math.MaxInt64-50)
+}
+
+// CanMultiplyBig6 reports whether a.MultiplyBig6(b) will not overflow.
+func CanMultiplyBig6(a Decimal4, b Decimal6) bool {
+	return productFits(int64(a/100), int64(b), math.MaxInt64-5000)
+}
+
+// CanMultiplyInt reports whether a.MultiplyInt(x) will not overflow.
+func CanMultiplyInt(a Decimal4, x int) bool {
+	return productFits(int64(a), int64(x), math.MaxInt64)
+}
+
+// CanDivide reports whether a.Divide(b) will not overflow or divide by zero.
+func CanDivide(a, b Decimal4) bool {
+	return b != 0 && a >= -DivideMax && a <= DivideMax
+}
+
+// CanDivideInt reports whether a.DivideInt(x) will not overflow or divide by zero.
+func CanDivideInt(a Decimal4, x int) bool {
+	return x != 0 && a >= -DivideIntMax && a <= DivideIntMax
+}
+
+// CanDivideBig reports whether a.DivideBig(b) will not overflow or divide by zero.
+func CanDivideBig(a, b Decimal4) bool {
+	if b == 0 || a < -DivideBigMax || a > DivideBigMax {
+		return false
+	}
+	q := a * 1000 / b // result with 3 decimal places, must fit after * 10
+	return q >= math.MinInt64/10 && q <= math.MaxInt64/10
+}
@@ -0,0 +1,119 @@
+package decimal4
+
+import (
+	"math/rand"
+	"testing"
+)
+
+type panicHandler struct{}
+
+func (panicHandler) OnOverflow(op string, operands ...any) { panic(op) }
+func (panicHandler) OnInexact(op string, operands ...any)  {}
+
+// panics reports whether f panics.
+func panics(f func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	f()
+	return false
+}
+
+func TestLimits(t *testing.T) {
+	type input struct {
+		name string
+		can  bool
+		want bool
+	}
+	data := []input{
+		{"Multiply limit", CanMultiply(MultiplyMax, New(1)), true},
+		{"Multiply limit negative", CanMultiply(-MultiplyMax, New(1)), true},
+		{"Multiply over limit", CanMultiply(MultiplyMax+1, New(1)), false},
+		{"Multiply 92 billion", CanMultiply(New(92000000000), New(1)), true},
+		{"Multiply 93 billion", CanMultiply(New(93000000000), New(1)), false},
+		{"Multiply fractions", CanMultiply(New(1000000), New(.0001)), true},
+		{"Multiply6 limit", CanMultiply6(Multiply6Max, NewDecimal6(1)), true},
+		{"Multiply6 over limit", CanMultiply6(Multiply6Max, NewDecimal6(1.000001)), false},
+		{"MultiplyBig limit", CanMultiplyBig(MultiplyBigMax, New(1)), true},
+		{"MultiplyBig limit swapped", CanMultiplyBig(New(-1), MultiplyBigMax), true},
+		{"MultiplyBig over limit", CanMultiplyBig(MultiplyBigMax+100, New(1)), false},
+		{"MultiplyBig 9 trillion", CanMultiplyBig(New(3000000), New(3000000)), true},
+		{"MultiplyBig 10 trillion", CanMultiplyBig(New(1000000), New(10000000)), false},
+		{"MultiplyBig MinDecimal4", CanMultiplyBig(MinDecimal4, New(1)), false},
+		{"MultiplyBig6 limit", CanMultiplyBig6(MultiplyBig6Max, NewDecimal6(1)), true},
+		{"MultiplyBig6 over limit", CanMultiplyBig6(MultiplyBig6Max+100, NewDecimal6(1)), false},
+		{"MultiplyInt", CanMultiplyInt(MaxDecimal4/2, 2), true},
+		{"MultiplyInt over", CanMultiplyInt(MaxDecimal4/2, 3), false},
+		{"Divide limit", CanDivide(DivideMax, New(.0001)), true},
+		{"Divide over limit", CanDivide(-DivideMax-1, New(1)), false},
+		{"Divide by zero", CanDivide(New(1), 0), false},
+		{"DivideBig limit", CanDivideBig(-DivideBigMax, New(1)), true},
+		{"DivideBig by zero", CanDivideBig(0, 0), false},
+		{"DivideBig result over limit", CanDivideBig(DivideBigMax/2, New(.0001)), false},
+		{"DivideInt limit", CanDivideInt(DivideIntMax, -1), true},
+		{"DivideInt over limit", CanDivideInt(DivideIntMax+1, 1), false},
+		{"DivideInt by zero", CanDivideInt(New(1), 0), false},
+	}
+	for _, v := range data {
+		if v.can != v.want {
+			t.Errorf("%s should be %t", v.name, v.want)
+		}
+	}
+	if MultiplyMax.String() != "92233720368.5477" || Multiply6Max.String() != "922337203.6854" {
+		t.Error("unexpected limits", MultiplyMax, Multiply6Max)
+	}
+}
+
+// TestLimitsAgree checks that when a Can function reports true, the method does not panic
+// and returns the correctly rounded result.
+func TestLimitsAgree(t *testing.T) {
+	defer SetHandler(SetHandler(panicHandler{}))
+	rnd := rand.New(rand.NewSource(3))
+	random := func() Decimal4 {
+		return Decimal4(rnd.Int63()>>uint(rnd.Intn(63)) - rnd.Int63()>>uint(rnd.Intn(63)))
+	}
+	for i := 0; i < 20000; i++ {
+		a, b, n := random(), random(), int(random()>>40)
+		if CanMultiply(a, b) {
+			want, _ := mulDiv(int64(a), int64(b), 10000, RoundHalfUp)
+			if panics(func() { a.Multiply(b) }) || a.Multiply(b) != Decimal4(want) {
+				t.Fatalf("Multiply(%d, %d) should be %d", a, b, want)
+			}
+		}
+		if CanMultiply6(a, Decimal6(b)) {
+			want, _ := mulDiv(int64(a), int64(b), 1000000, RoundHalfUp)
+			if panics(func() { a.Multiply6(Decimal6(b)) }) || a.Multiply6(Decimal6(b)) != Decimal4(want) {
+				t.Fatalf("Multiply6(%d, %d) should be %d", a, b, want)
+			}
+		}
+		if CanMultiplyBig(a, b) {
+			x, y := a, b
+			if Abs(x) <= Abs(y) {
+				x, y = y, x
+			}
+			want, _ := mulDiv(int64(x/100), int64(y), 100, RoundHalfUp)
+			if panics(func() { a.MultiplyBig(b) }) || a.MultiplyBig(b) != Decimal4(want) {
+				t.Fatalf("MultiplyBig(%d, %d) should be %d", a, b, want)
+			}
+		}
+		if CanMultiplyBig6(a, Decimal6(b)) {
+			want, _ := mulDiv(int64(a/100), int64(b), 10000, RoundHalfUp)
+			if panics(func() { a.MultiplyBig6(Decimal6(b)) }) || a.MultiplyBig6(Decimal6(b)) != Decimal4(want) {
+				t.Fatalf("MultiplyBig6(%d, %d) should be %d", a, b, want)
+			}
+		}
+		if CanMultiplyInt(a, n) && panics(func() { a.MultiplyInt(n) }) {
+			t.Fatalf("MultiplyInt(%d, %d) should not panic", a, n)
+		}
+		if CanDivide(a, b) {
+			want, _ := mulDiv(int64(a), 10000, int64(b), RoundHalfUp)
+			if panics(func() { a.Divide(b) }) || a.Divide(b) != Decimal4(want) {
+				t.Fatalf("Divide(%d, %d) should be %d", a, b, want)
+			}
+		}
+		if CanDivideBig(a, b) && panics(func() { a.DivideBig(b) }) {
+			t.Fatalf("DivideBig(%d, %d) should not panic", a, b)
+		}
+		if CanDivideInt(a, n) && panics(func() { a.DivideInt(n) }) {
+			t.Fatalf("DivideInt(%d, %d) should not panic", a, n)
+		}
+	}
+}
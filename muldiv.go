@@ -0,0 +1,59 @@
+package decimal4
+
+import (
+	"math"
+	"math/bits"
+)
+
+// mulDiv returns a * b / c, rounded using mode.
+// The product is kept in 128 bits, so only the result must fit in int64.
+// ok is false if c is zero or the result overflows.
+func mulDiv(a, b, c int64, mode RoundingMode) (result int64, ok bool) {
+	if c == 0 {
+		return 0, false
+	}
+	neg := (a < 0) != (b < 0) != (c < 0)
+	ua, ub, uc := absUint64(a), absUint64(b), absUint64(c)
+	hi, lo := bits.Mul64(ua, ub)
+	if hi >= uc {
+		return 0, false
+	}
+	q, r := bits.Div64(hi, lo, uc)
+	if mode.roundAway(neg, r != 0, compareHalf(r, uc), q&1 == 1) {
+		q++
+	}
+	return signedInt64(q, neg)
+}
+
+// compareHalf compares remainder r to half of divisor d, returning -1, 0 or 1.
+func compareHalf(r, d uint64) int {
+	switch {
+	case r < d-r: // r*2 < d without overflowing
+		return -1
+	case r == d-r:
+		return 0
+	}
+	return 1
+}
+
+// absUint64 returns the magnitude of x, valid for math.MinInt64.
+func absUint64(x int64) uint64 {
+	if x < 0 {
+		return uint64(-(x + 1)) + 1
+	}
+	return uint64(x)
+}
+
+// signedInt64 applies the sign to magnitude q, reporting false if it does not fit.
+func signedInt64(q uint64, neg bool) (int64, bool) {
+	if neg {
+		if q > 1<<63 {
+			return 0, false
+		}
+		return -int64(q), true // -int64(1<<63) wraps to math.MinInt64
+	}
+	if q > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(q), true
+}
@@ -0,0 +1,279 @@
+package decimal4
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// Powers, exponentials and logarithms are evaluated with big.Float at increasing
+// precision until the rounded result is certain, so results are correctly rounded.
+
+// zivMaxPrec is the largest precision tried. A result still undecided at this precision
+// is taken to lie exactly on a rounding boundary, as for 1.21 ^ .5 = 1.1.
+const zivMaxPrec = 4096
+
+// roundFloat returns f rounded to an integer using mode.
+func roundFloat(f *big.Float, mode RoundingMode) *big.Int {
+	r, _ := f.Rat(nil)
+	return bigQuo(r.Num(), r.Denom(), mode)
+}
+
+// zivRound returns the value computed by eval, in units of places decimal places, rounded using mode.
+// eval(prec) must return a result with a relative error below 2^(40-prec).
+func zivRound(places int, mode RoundingMode, eval func(prec uint) *big.Float) (int64, error) {
+	var q *big.Int
+	for prec := uint(128); ; prec *= 2 {
+		v := eval(prec)
+		v.Mul(v, new(big.Float).SetInt64(pow10[places]))
+		e := new(big.Float).SetMantExp(v, 40-int(prec))
+		e.Abs(e)
+		lo := new(big.Float).SetPrec(prec+64).Sub(v, e)
+		hi := new(big.Float).SetPrec(prec+64).Add(v, e)
+		q = roundFloat(lo, mode)
+		if q.Cmp(roundFloat(hi, mode)) == 0 {
+			break
+		}
+		if prec >= zivMaxPrec {
+			// snap to the nearest half unit, then round exactly
+			h := roundFloat(v.Mul(v, big.NewFloat(2)), RoundHalfEven)
+			q = bigQuo(h, big.NewInt(2), mode)
+			break
+		}
+	}
+	if !q.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return q.Int64(), nil
+}
+
+// tinyResult returns a non-zero result smaller than 10^-8 rounded to units using mode.
+func tinyResult(neg bool, mode RoundingMode) int64 {
+	if !mode.roundAway(neg, true, -1, false) {
+		return 0
+	}
+	if neg {
+		return -1
+	}
+	return 1
+}
+
+// ln2 returns the natural log of 2 with prec bits.
+func ln2(prec uint) *big.Float {
+	third := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), big.NewFloat(3))
+	return atanhTwice(third, prec)
+}
+
+// atanhTwice returns 2 * atanh(z) = ln((1 + z) / (1 - z)) for small |z|.
+func atanhTwice(z *big.Float, prec uint) *big.Float {
+	z2 := new(big.Float).SetPrec(prec).Mul(z, z)
+	power := new(big.Float).SetPrec(prec).Set(z)
+	sum := new(big.Float).SetPrec(prec).Set(z)
+	term := new(big.Float).SetPrec(prec)
+	for k := int64(3); power.Sign() != 0; k += 2 {
+		power.Mul(power, z2)
+		term.Quo(power, new(big.Float).SetInt64(k))
+		if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	return sum.Mul(sum, big.NewFloat(2))
+}
+
+// lnFloat returns the natural log of x > 0.
+func lnFloat(x *big.Float, prec uint) *big.Float {
+	p := prec + 32
+	m := new(big.Float).SetPrec(p)
+	k := x.MantExp(m) // x = m * 2^k, m in [.5, 1)
+	if m.Cmp(big.NewFloat(math.Sqrt2/2)) < 0 {
+		m.Mul(m, big.NewFloat(2))
+		k--
+	}
+	// ln(m) = 2 * atanh((m - 1) / (m + 1)), m in [.707, 1.414)
+	one := big.NewFloat(1)
+	z := new(big.Float).SetPrec(p).Sub(m, one)
+	z.Quo(z, new(big.Float).SetPrec(p).Add(m, one))
+	result := atanhTwice(z, p)
+	if k != 0 {
+		result.Add(result, ln2(p).Mul(ln2(p), new(big.Float).SetInt64(int64(k))))
+	}
+	return result
+}
+
+// expFloat returns e^x, |x| < 100.
+func expFloat(x *big.Float, prec uint) *big.Float {
+	const halvings = 16
+	p := prec + 64
+	// x = k * ln2 + r, e^x = 2^k * (e^(r / 2^halvings))^(2^halvings)
+	l2 := ln2(p)
+	kf, _ := new(big.Float).Quo(x, l2).Float64()
+	k := int64(math.Round(kf))
+	r := new(big.Float).SetPrec(p).Mul(l2, new(big.Float).SetInt64(k))
+	r.Sub(x, r)
+	r.SetMantExp(r, -halvings)
+	sum := new(big.Float).SetPrec(p).SetInt64(1)
+	term := new(big.Float).SetPrec(p).SetInt64(1)
+	for n := int64(1); ; n++ {
+		term.Mul(term, r)
+		term.Quo(term, new(big.Float).SetInt64(n))
+		if term.Sign() == 0 || term.MantExp(nil) < -int(p) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	for i := 0; i < halvings; i++ {
+		sum.Mul(sum, sum)
+	}
+	return sum.SetMantExp(sum, int(k))
+}
+
+// scaledFloat returns x / 10^places.
+func scaledFloat(x int64, places int, prec uint) *big.Float {
+	f := new(big.Float).SetPrec(prec).SetInt64(x)
+	return f.Quo(f, new(big.Float).SetInt64(pow10[places]))
+}
+
+// powInt returns (x / 10^places)^n in units of places decimal places, rounded using mode.
+func powInt(x int64, places int, n int, mode RoundingMode) (int64, error) {
+	if n == 0 {
+		return pow10[places], nil
+	}
+	if x == 0 {
+		if n < 0 {
+			return 0, ErrDivisionByZero
+		}
+		return 0, nil
+	}
+	neg := x < 0 && n%2 != 0
+	if absUint64(x) == uint64(pow10[places]) {
+		if neg {
+			return -pow10[places], nil
+		}
+		return pow10[places], nil
+	}
+	m := uint64(n)
+	if n < 0 {
+		m = -m
+	}
+	// log2 of the result in units, to reject overflow and underflow before computing
+	unitBits := float64(places) * math.Log2(10)
+	size := float64(n)*(math.Log2(float64(absUint64(x)))-unitBits) + unitBits
+	if size > 64 {
+		return 0, ErrOverflow
+	}
+	if size < -64 {
+		return tinyResult(neg, mode), nil
+	}
+
+	if m <= (1<<14)/uint64(bits.Len64(absUint64(x))) {
+		// exact: x^m / 10^(places * (m - 1)), or 10^(places * (m + 1)) / x^m for negative n
+		power := new(big.Int).Exp(big.NewInt(x), new(big.Int).SetUint64(m), nil)
+		if n > 0 {
+			return bigResult(bigQuo(power, bigPow10(places*(n-1)), mode))
+		}
+		return bigResult(bigQuo(bigPow10(places*(1-n)), power, mode))
+	}
+	return zivRound(places, mode, func(prec uint) *big.Float {
+		p := prec + 64
+		base := scaledFloat(x, places, p)
+		result := new(big.Float).SetPrec(p).SetInt64(1)
+		for e := m; e > 0; e >>= 1 {
+			if e&1 == 1 {
+				result.Mul(result, base)
+			}
+			base.Mul(base, base)
+		}
+		if n < 0 {
+			result.Quo(new(big.Float).SetPrec(p).SetInt64(1), result)
+		}
+		return result
+	})
+}
+
+func bigResult(q *big.Int) (int64, error) {
+	if !q.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return q.Int64(), nil
+}
+
+// PowInt returns this raised to the integer power n, rounded once to 4 decimal places using mode.
+// Returns ErrOverflow if the result does not fit, ErrDivisionByZero for 0 to a negative power.
+func (this Decimal4) PowInt(n int, mode RoundingMode) (Decimal4, error) {
+	x, err := powInt(int64(this), 4, n, mode)
+	return Decimal4(x), err
+}
+
+// PowInt returns this raised to the integer power n, rounded once to 6 decimal places using mode.
+// Returns ErrOverflow if the result does not fit, ErrDivisionByZero for 0 to a negative power.
+func (this Decimal6) PowInt(n int, mode RoundingMode) (Decimal6, error) {
+	x, err := powInt(int64(this), 6, n, mode)
+	return Decimal6(x), err
+}
+
+// Exp returns e raised to this, correctly rounded to 6 decimal places using mode.
+// Returns ErrOverflow if the result does not fit (this above about 29.85).
+func (this Decimal6) Exp(mode RoundingMode) (Decimal6, error) {
+	if this == 0 {
+		return 1000000, nil
+	}
+	if this > 31000000 {
+		return 0, ErrOverflow
+	}
+	if this < -20000000 {
+		return Decimal6(tinyResult(false, mode)), nil
+	}
+	x, err := zivRound(6, mode, func(prec uint) *big.Float {
+		return expFloat(scaledFloat(int64(this), 6, prec+64), prec)
+	})
+	return Decimal6(x), err
+}
+
+// Ln returns the natural log of this, correctly rounded to 6 decimal places using mode.
+// Returns ErrDomain if this is not positive.
+func (this Decimal6) Ln(mode RoundingMode) (Decimal6, error) {
+	if this <= 0 {
+		return 0, ErrDomain
+	}
+	if this == 1000000 {
+		return 0, nil
+	}
+	x, err := zivRound(6, mode, func(prec uint) *big.Float {
+		return lnFloat(scaledFloat(int64(this), 6, prec+64), prec)
+	})
+	return Decimal6(x), err
+}
+
+// Pow returns this raised to the power y, correctly rounded to 6 decimal places using mode.
+// For example (1 + APR/12)^12 - 1 converts APR to APY, and 1.05^2.5 compounds 5% over 2.5 years.
+// A negative this requires an integer y, otherwise Pow returns ErrDomain.
+// Returns ErrDivisionByZero for 0 to a negative power, ErrOverflow if the result does not fit.
+func (this Decimal6) Pow(y Decimal6, mode RoundingMode) (Decimal6, error) {
+	if y%1000000 == 0 && int64(y/1000000) == int64(int(y/1000000)) {
+		return this.PowInt(int(y/1000000), mode)
+	}
+	if this < 0 {
+		return 0, ErrDomain
+	}
+	if this == 0 {
+		if y < 0 {
+			return 0, ErrDivisionByZero
+		}
+		return 0, nil
+	}
+	// y * ln(this) decides overflow and underflow, as for Exp
+	t := float64(y) / 1e6 * math.Log(float64(this)/1e6)
+	if t > 31 {
+		return 0, ErrOverflow
+	}
+	if t < -20 {
+		return Decimal6(tinyResult(false, mode)), nil
+	}
+	x, err := zivRound(6, mode, func(prec uint) *big.Float {
+		p := prec + 64
+		l := lnFloat(scaledFloat(int64(this), 6, p), p)
+		return expFloat(l.Mul(l, scaledFloat(int64(y), 6, p)), prec)
+	})
+	return Decimal6(x), err
+}
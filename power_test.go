@@ -0,0 +1,137 @@
+package decimal4
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPowInt(t *testing.T) {
+	type input struct {
+		x    float64
+		n    int
+		mode RoundingMode
+		want float64
+	}
+	data := []input{
+		{1.5, 2, RoundHalfUp, 2.25},
+		{1.0001, 2, RoundHalfEven, 1.0002},
+		{1.0001, 2, RoundUp, 1.0003},
+		{1.0001, 10000, RoundHalfUp, 2.7181},
+		{1.5, -3, RoundHalfUp, .2963},
+		{-1.1, 3, RoundFloor, -1.331},
+		{-2, 4, RoundHalfUp, 16},
+		{0, 0, RoundHalfUp, 1},
+		{7, 0, RoundHalfUp, 1},
+		{.01, 3, RoundHalfUp, 0},
+		{.01, 3, RoundUp, .0001},
+		{.5, 1000, RoundCeiling, .0001},
+		{-.5, 1001, RoundFloor, -.0001},
+		{1, 1 << 30, RoundDown, 1},
+		{1, 1317624576693539402, RoundHalfUp, 1},
+		{-1, 1317624576693539403, RoundHalfUp, -1},
+		{-1, -1317624576693539402, RoundHalfUp, 1},
+	}
+	for i, v := range data {
+		x, err := New(v.x).PowInt(v.n, v.mode)
+		if err != nil || x != New(v.want) {
+			t.Errorf("data[%d]: %f ^ %d should be %f, but is %s %v", i, v.x, v.n, v.want, x, err)
+		}
+	}
+	if _, err := New(10).PowInt(15, RoundHalfUp); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+	if _, err := New(1.0001).PowInt(1<<30, RoundHalfUp); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+	if _, err := New(0).PowInt(-1, RoundHalfUp); err != ErrDivisionByZero {
+		t.Error("expected ErrDivisionByZero, got", err)
+	}
+	if x, err := NewDecimal6(1).PowInt(math.MinInt64, RoundHalfUp); x != NewDecimal6(1) || err != nil {
+		t.Error("1 ^ MinInt64 should be 1, but is", x, err)
+	}
+	if x, _ := NewDecimal6(1.000001).PowInt(1000000, RoundHalfUp); x != NewDecimal6(2.71828) {
+		t.Error("1.000001 ^ 1000000 should be 2.718280, but is", x)
+	}
+}
+
+func TestExpLn(t *testing.T) {
+	type input struct {
+		x       float64
+		exp, ln float64
+		expErr  error
+		lnErr   error
+	}
+	data := []input{
+		{1, 2.718282, 0, nil, nil},
+		{-1, .367879, 0, nil, ErrDomain},
+		{.05, 1.051271, -2.995732, nil, nil},
+		{2, 7.389056, .693147, nil, nil},
+		{.5, 1.648721, -.693147, nil, nil},
+		{.000001, 1.000001, -13.815511, nil, nil},
+		{-15, 0, 0, nil, ErrDomain},
+		{0, 1, 0, nil, ErrDomain},
+		{31, 0, 3.433987, ErrOverflow, nil},
+	}
+	for i, v := range data {
+		exp, expErr := NewDecimal6(v.x).Exp(RoundHalfUp)
+		ln, lnErr := NewDecimal6(v.x).Ln(RoundHalfUp)
+		if expErr != v.expErr || expErr == nil && exp != NewDecimal6(v.exp) {
+			t.Errorf("data[%d]: Exp should be %f %v, but is %s %v", i, v.exp, v.expErr, exp, expErr)
+		}
+		if lnErr != v.lnErr || lnErr == nil && ln != NewDecimal6(v.ln) {
+			t.Errorf("data[%d]: Ln should be %f %v, but is %s %v", i, v.ln, v.lnErr, ln, lnErr)
+		}
+	}
+	if x, _ := NewDecimal6(29.8).Exp(RoundHalfUp); x != 8749345381880233932 {
+		t.Error("Exp(29.8) should be 8749345381880.233932, but is", x)
+	}
+	if x, _ := NewDecimal6(1.000001).Ln(RoundHalfUp); x != 1 {
+		t.Error("Ln(1.000001) should be .000001, but is", x)
+	}
+	if x, _ := Decimal6(9000000000000000000).Ln(RoundHalfUp); x != NewDecimal6(29.828246) {
+		t.Error("Ln(9e12) should be 29.828246, but is", x)
+	}
+	if x, _ := NewDecimal6(-15).Exp(RoundUp); x != 1 {
+		t.Error("Exp(-15) RoundUp should be .000001, but is", x)
+	}
+
+	// Ln and Exp are inverses: Exp of Ln rounded down and up brackets x
+	for _, x := range []Decimal6{1, 123456, 1000000, 2500000, 9999999, 29000000} {
+		lo, _ := x.Ln(RoundFloor)
+		hi, _ := x.Ln(RoundCeiling)
+		a, _ := lo.Exp(RoundFloor)
+		b, _ := hi.Exp(RoundCeiling)
+		if a > x || b < x {
+			t.Errorf("Exp(Ln(%s)): %s to %s does not bracket", x, a, b)
+		}
+	}
+}
+
+func TestPow(t *testing.T) {
+	type input struct {
+		x, y float64
+		mode RoundingMode
+		want float64
+		err  error
+	}
+	data := []input{
+		{1.005, 12, RoundHalfUp, 1.061678, nil}, // APY for 6% APR compounded monthly
+		{1.05, 2.5, RoundHalfUp, 1.129726, nil},
+		{2, .5, RoundHalfUp, 1.414214, nil},
+		{1.21, .5, RoundDown, 1.1, nil},
+		{1.21, .5, RoundUp, 1.1, nil},
+		{.5, -1.5, RoundHalfUp, 2.828427, nil},
+		{-2, 3, RoundHalfUp, -8, nil},
+		{-2, .5, RoundHalfUp, 0, ErrDomain},
+		{0, .5, RoundHalfUp, 0, nil},
+		{0, -.5, RoundHalfUp, 0, ErrDivisionByZero},
+		{10, 13.5, RoundHalfUp, 0, ErrOverflow},
+		{.1, 12.5, RoundHalfUp, 0, nil},
+	}
+	for i, v := range data {
+		x, err := NewDecimal6(v.x).Pow(NewDecimal6(v.y), v.mode)
+		if err != v.err || err == nil && x != NewDecimal6(v.want) {
+			t.Errorf("data[%d]: %f ^ %f should be %f %v, but is %s %v", i, v.x, v.y, v.want, v.err, x, err)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package decimal4
+
+import (
+	"log"
+	"math/big"
+)
+
+// RoundingMode determines how a result is rounded when digits are dropped.
+type RoundingMode int
+
+const (
+	RoundHalfUp   RoundingMode = iota // .5 rounds away from zero, as used by Multiply, Divide, Round0-Round3
+	RoundHalfEven                     // .5 rounds to even digit (banker's rounding)
+	RoundHalfDown                     // .5 rounds toward zero
+	RoundDown                         // toward zero (truncate)
+	RoundUp                           // away from zero
+	RoundFloor                        // toward negative infinity
+	RoundCeiling                      // toward positive infinity
+)
+
+// roundAway reports whether a truncated quotient should be increased in magnitude by 1.
+// neg is the sign of the result, inexact is true if the remainder is not zero,
+// half compares the remainder to half the divisor (-1, 0, 1), odd is true if the quotient is odd.
+func (mode RoundingMode) roundAway(neg, inexact bool, half int, odd bool) bool {
+	if !inexact {
+		return false
+	}
+	switch mode {
+	case RoundHalfEven:
+		return half > 0 || half == 0 && odd
+	case RoundHalfDown:
+		return half > 0
+	case RoundDown:
+		return false
+	case RoundUp:
+		return true
+	case RoundFloor:
+		return neg
+	case RoundCeiling:
+		return !neg
+	}
+	return half >= 0 // RoundHalfUp
+}
+
+// pow10 holds powers of 10 that fit in int64, pow10[n] = 10^n.
+var pow10 = [19]int64{
+	1, 10, 100, 1000, 10000, 100000, 1000000, 10000000, 100000000, 1000000000,
+	10000000000, 100000000000, 1000000000000, 10000000000000, 100000000000000,
+	1000000000000000, 10000000000000000, 100000000000000000, 1000000000000000000,
+}
+
+// RoundPlaces returns this rounded to places (0-4) decimal places using mode.
+// Result still has 4 implied decimal places.
+func (this Decimal4) RoundPlaces(places int, mode RoundingMode) Decimal4 {
+	if places < 0 || places > 4 {
+		log.Panic("Decimal4 RoundPlaces invalid places=", places)
+	}
+	q, ok := roundToUnit(int64(this), pow10[4-places], mode)
+	if !ok {
+		overflow("Decimal4 RoundPlaces", this, places)
+	}
+	return Decimal4(q)
+}
+
+// roundToUnit rounds x to a multiple of unit using mode.
+func roundToUnit(x, unit int64, mode RoundingMode) (int64, bool) {
+	q, ok := mulDiv(x, 1, unit, mode)
+	if !ok {
+		return 0, false
+	}
+	return mulDiv(q, unit, 1, mode)
+}
+
+// Rounding combines decimal places (0-4) and RoundingMode.
+type Rounding struct {
+	Places int
+	Mode   RoundingMode
+}
+
+// Round returns x rounded to r.Places using r.Mode.
+func (r Rounding) Round(x Decimal4) Decimal4 {
+	return x.RoundPlaces(r.Places, r.Mode)
+}
+
+// mulDiv returns a * b / c as a Decimal4 rounded once to r.Places using r.Mode.
+func (r Rounding) mulDiv(a, b, c int64) (Decimal4, bool) {
+	if c == 0 || r.Places < 0 || r.Places > 4 {
+		return 0, false
+	}
+	x := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(a), big.NewInt(b)), big.NewInt(c))
+	q, ok := r.ratRound(x)
+	return Decimal4(q), ok
+}
+
+// ratRound returns x (in Decimal4 units) rounded to r.Places using r.Mode.
+func (r Rounding) ratRound(x *big.Rat) (int64, bool) {
+	unit := big.NewInt(pow10[4-r.Places])
+	q := bigQuo(x.Num(), new(big.Int).Mul(x.Denom(), unit), r.Mode)
+	q.Mul(q, unit)
+	if !q.IsInt64() {
+		return 0, false
+	}
+	return q.Int64(), true
+}
+
+// bigQuo returns n / d rounded using mode, d must not be zero.
+func bigQuo(n, d *big.Int, mode RoundingMode) *big.Int {
+	q, m := new(big.Int).QuoRem(n, d, new(big.Int))
+	neg := n.Sign()*d.Sign() < 0
+	m.Abs(m)
+	half := m.Cmp(new(big.Int).Sub(new(big.Int).Abs(d), m))
+	if mode.roundAway(neg, m.Sign() != 0, half, q.Bit(0) == 1) {
+		if neg {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// RoundToIncrement returns this rounded to a multiple of inc using mode,
+// for example inc 500 (.05) for Swiss cash or 1250 (1/8) for price ticks.
+func (this Decimal4) RoundToIncrement(inc Decimal4, mode RoundingMode) Decimal4 {
+	return this.RoundToIncrementOffset(inc, 0, mode)
+}
+
+// RoundToIncrementOffset returns this rounded to a multiple of inc plus offset using mode,
+// for example inc 100 (.01) and offset 90 (.009) for fuel prices ending in 9/10 of a cent.
+func (this Decimal4) RoundToIncrementOffset(inc, offset Decimal4, mode RoundingMode) Decimal4 {
+	if inc <= 0 {
+		log.Panic("Decimal4 RoundToIncrement invalid inc=", inc)
+	}
+	a := this - offset
+	if (offset > 0 && a > this) || (offset < 0 && a < this) {
+		overflow("Decimal4 RoundToIncrement", this, offset)
+	}
+	q, ok := roundToUnit(int64(a), int64(inc), mode)
+	result := Decimal4(q) + offset
+	if !ok || (offset > 0 && result < Decimal4(q)) || (offset < 0 && result > Decimal4(q)) {
+		overflow("Decimal4 RoundToIncrement", this, inc)
+	}
+	return result
+}
+
+// negated returns the mode that gives the same result when applied to -x and the result negated.
+func (mode RoundingMode) negated() RoundingMode {
+	switch mode {
+	case RoundFloor:
+		return RoundCeiling
+	case RoundCeiling:
+		return RoundFloor
+	}
+	return mode
+}
+
+// MulDiv returns a * b / c rounded using mode, with a 128-bit intermediate product.
+// Returns ErrDivisionByZero if c is 0, ErrOverflow if the result does not fit in int64.
+func (mode RoundingMode) MulDiv(a, b, c int64) (int64, error) {
+	if c == 0 {
+		return 0, ErrDivisionByZero
+	}
+	q, ok := mulDiv(a, b, c, mode)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return q, nil
+}
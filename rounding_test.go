@@ -0,0 +1,72 @@
+package decimal4
+
+import "testing"
+
+func TestRoundPlaces(t *testing.T) {
+	type input struct {
+		val    float64
+		places int
+		mode   RoundingMode
+		result float64
+	}
+	data := []input{
+		{1.235, 2, RoundHalfUp, 1.24},
+		{-1.235, 2, RoundHalfUp, -1.24},
+		{1.235, 2, RoundHalfEven, 1.24},
+		{1.245, 2, RoundHalfEven, 1.24},
+		{-1.245, 2, RoundHalfEven, -1.24},
+		{1.245, 2, RoundHalfDown, 1.24},
+		{1.2451, 2, RoundHalfDown, 1.25},
+		{1.2499, 2, RoundDown, 1.24},
+		{-1.2499, 2, RoundDown, -1.24},
+		{1.2401, 2, RoundUp, 1.25},
+		{-1.2401, 2, RoundUp, -1.25},
+		{1.2499, 2, RoundFloor, 1.24},
+		{-1.2401, 2, RoundFloor, -1.25},
+		{1.2401, 2, RoundCeiling, 1.25},
+		{-1.2499, 2, RoundCeiling, -1.24},
+		{1.24, 2, RoundUp, 1.24},
+		{2.5, 0, RoundHalfEven, 2},
+		{3.5, 0, RoundHalfEven, 4},
+		{1.2345, 4, RoundUp, 1.2345},
+	}
+	for i, v := range data {
+		result := New(v.val).RoundPlaces(v.places, v.mode)
+		if result != New(v.result) {
+			t.Errorf("data[%d]: result should be %f, but is %s", i, v.result, result)
+		}
+	}
+}
+
+func TestRoundToIncrement(t *testing.T) {
+	type input struct {
+		val    float64
+		inc    float64
+		offset float64
+		mode   RoundingMode
+		result float64
+	}
+	data := []input{
+		{1.02, .05, 0, RoundHalfUp, 1},
+		{1.025, .05, 0, RoundHalfUp, 1.05},
+		{-1.025, .05, 0, RoundHalfUp, -1.05},
+		{1.025, .05, 0, RoundHalfEven, 1},
+		{1.075, .05, 0, RoundHalfEven, 1.1},
+		{10.1, .25, 0, RoundHalfUp, 10},
+		{10.13, .25, 0, RoundCeiling, 10.25},
+		{99.1876, .125, 0, RoundHalfUp, 99.25},
+		{99.1874, .125, 0, RoundDown, 99.125},
+		{12.34, .1, 0, RoundHalfUp, 12.3},
+		{12.35, .1, 0, RoundHalfUp, 12.4},
+		{3.4512, .01, .009, RoundHalfUp, 3.449},
+		{3.4552, .01, .009, RoundHalfUp, 3.459},
+		{3.4512, .01, .009, RoundCeiling, 3.459},
+		{0, .05, 0, RoundUp, 0},
+	}
+	for i, v := range data {
+		result := New(v.val).RoundToIncrementOffset(New(v.inc), New(v.offset), v.mode)
+		if result != New(v.result) {
+			t.Errorf("data[%d]: result should be %f, but is %s", i, v.result, result)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package decimal4
+
+import "math"
+
+// Limits of Decimal4, about -922 to +922 trillion.
+const (
+	MaxDecimal4 Decimal4 = math.MaxInt64
+	MinDecimal4 Decimal4 = math.MinInt64
+)
+
+// Saturating methods clamp a result that does not fit to MaxDecimal4 or MinDecimal4
+// instead of panicking, and report whether the result was clamped.
+// Use them for metrics and dashboards, not for money.
+
+// saturate returns the limit with the sign of neg.
+func saturate(neg bool) Decimal4 {
+	if neg {
+		return MinDecimal4
+	}
+	return MaxDecimal4
+}
+
+// satMulDiv returns a * b / c rounded half up, clamped if it does not fit.
+func satMulDiv(a, b, c int64) (Decimal4, bool) {
+	if x, ok := mulDiv(a, b, c, RoundHalfUp); ok {
+		return Decimal4(x), false
+	}
+	return saturate((a < 0) != (b < 0) != (c < 0)), true
+}
+
+// AddSat returns this + x, clamped to MaxDecimal4 or MinDecimal4.
+func (this Decimal4) AddSat(x Decimal4) (Decimal4, bool) {
+	a := this + x
+	if (x > 0 && a < this) || (x < 0 && a > this) {
+		return saturate(x < 0), true
+	}
+	return a, false
+}
+
+// SubSat returns this - x, clamped to MaxDecimal4 or MinDecimal4.
+func (this Decimal4) SubSat(x Decimal4) (Decimal4, bool) {
+	a := this - x
+	if (x > 0 && a > this) || (x < 0 && a < this) {
+		return saturate(x > 0), true
+	}
+	return a, false
+}
+
+// MultiplySat returns this * x rounded to 4 decimal places, clamped to MaxDecimal4 or MinDecimal4.
+func (this Decimal4) MultiplySat(x Decimal4) (Decimal4, bool) {
+	return satMulDiv(int64(this), int64(x), 10000)
+}
+
+// Multiply6Sat returns this * x rounded to 4 decimal places, clamped to MaxDecimal4 or MinDecimal4.
+func (this Decimal4) Multiply6Sat(x Decimal6) (Decimal4, bool) {
+	return satMulDiv(int64(this), int64(x), 1000000)
+}
+
+// MultiplyIntSat returns this * x, clamped to MaxDecimal4 or MinDecimal4.
+func (this Decimal4) MultiplyIntSat(x int) (Decimal4, bool) {
+	return satMulDiv(int64(this), int64(x), 1)
+}
+
+// DivideSat returns this / x rounded to 4 decimal places, clamped to MaxDecimal4 or MinDecimal4.
+// Division by zero saturates with the sign of this, 0 / 0 returns 0 and true.
+func (this Decimal4) DivideSat(x Decimal4) (Decimal4, bool) {
+	if x == 0 {
+		if this == 0 {
+			return 0, true
+		}
+		return saturate(this < 0), true
+	}
+	return satMulDiv(int64(this), 10000, int64(x))
+}
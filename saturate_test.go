@@ -0,0 +1,37 @@
+package decimal4
+
+import "testing"
+
+func TestSaturate(t *testing.T) {
+	type input struct {
+		name      string
+		want      Decimal4
+		saturated bool
+		f         func() (Decimal4, bool)
+	}
+	data := []input{
+		{"AddSat", New(3), false, func() (Decimal4, bool) { return New(1).AddSat(New(2)) }},
+		{"AddSat max", MaxDecimal4, true, func() (Decimal4, bool) { return MaxDecimal4.AddSat(1) }},
+		{"AddSat min", MinDecimal4, true, func() (Decimal4, bool) { return MinDecimal4.AddSat(-1) }},
+		{"SubSat", New(-1), false, func() (Decimal4, bool) { return New(1).SubSat(New(2)) }},
+		{"SubSat max", MaxDecimal4, true, func() (Decimal4, bool) { return MaxDecimal4.SubSat(-1) }},
+		{"SubSat min", MinDecimal4, true, func() (Decimal4, bool) { return New(-1).SubSat(MaxDecimal4) }},
+		{"MultiplySat", New(2.5), false, func() (Decimal4, bool) { return New(1.25).MultiplySat(New(2)) }},
+		{"MultiplySat max", MaxDecimal4, true, func() (Decimal4, bool) { return New(-100000000000).MultiplySat(New(-100000)) }},
+		{"MultiplySat min", MinDecimal4, true, func() (Decimal4, bool) { return New(100000000000).MultiplySat(New(-100000)) }},
+		{"MultiplySat wide", New(10000000000000), false, func() (Decimal4, bool) { return New(100000000000).MultiplySat(New(100)) }},
+		{"Multiply6Sat", New(1.0513), false, func() (Decimal4, bool) { return New(1).Multiply6Sat(NewDecimal6(1.051271)) }},
+		{"Multiply6Sat min", MinDecimal4, true, func() (Decimal4, bool) { return MaxDecimal4.Multiply6Sat(NewDecimal6(-1.5)) }},
+		{"MultiplyIntSat", New(-6), false, func() (Decimal4, bool) { return New(2).MultiplyIntSat(-3) }},
+		{"MultiplyIntSat max", MaxDecimal4, true, func() (Decimal4, bool) { return MinDecimal4.MultiplyIntSat(-1) }},
+		{"DivideSat", New(3.3333), false, func() (Decimal4, bool) { return New(10).DivideSat(New(3)) }},
+		{"DivideSat max", MaxDecimal4, true, func() (Decimal4, bool) { return New(900000000000).DivideSat(New(.0001)) }},
+		{"DivideSat by zero", MinDecimal4, true, func() (Decimal4, bool) { return New(-1).DivideSat(0) }},
+		{"DivideSat 0/0", 0, true, func() (Decimal4, bool) { return Decimal4(0).DivideSat(0) }},
+	}
+	for _, v := range data {
+		if got, saturated := v.f(); got != v.want || saturated != v.saturated {
+			t.Errorf("%s should be %s %t, but is %s %t", v.name, v.want, v.saturated, got, saturated)
+		}
+	}
+}
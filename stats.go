@@ -0,0 +1,160 @@
+package decimal4
+
+import "math/big"
+
+// sqrtRat returns sqrt(n / d), n >= 0 and d > 0, rounded to an integer using mode.
+// The result is negated if neg is true.
+func sqrtRat(n, d *big.Int, neg bool, mode RoundingMode) *big.Int {
+	s := new(big.Int).Sqrt(new(big.Int).Quo(n, d)) // floor(sqrt(n / d))
+	// compare n / d with s^2 (exact) and (s + .5)^2 = (4s^2 + 4s + 1) / 4 (half)
+	low := new(big.Int).Mul(s, s)
+	low.Mul(low, d)
+	mid := new(big.Int).Lsh(s, 1)
+	mid.Add(mid, bigOne)
+	mid.Mul(mid, mid)
+	mid.Mul(mid, d)
+	half := new(big.Int).Lsh(n, 2).Cmp(mid)
+	if mode.roundAway(neg, n.Cmp(low) != 0, half, s.Bit(0) == 1) {
+		s.Add(s, bigOne)
+	}
+	if neg {
+		s.Neg(s)
+	}
+	return s
+}
+
+func sqrtScaled(x int64, places int, mode RoundingMode) (int64, error) {
+	if x < 0 {
+		return 0, ErrDomain
+	}
+	n := new(big.Int).Mul(big.NewInt(x), big.NewInt(pow10[places]))
+	return sqrtRat(n, bigOne, false, mode).Int64(), nil
+}
+
+// Sqrt returns the square root of this, correctly rounded to 4 decimal places using mode.
+// Returns ErrDomain if this is negative.
+func (this Decimal4) Sqrt(mode RoundingMode) (Decimal4, error) {
+	x, err := sqrtScaled(int64(this), 4, mode)
+	return Decimal4(x), err
+}
+
+// Sqrt returns the square root of this, correctly rounded to 6 decimal places using mode.
+// Returns ErrDomain if this is negative.
+func (this Decimal6) Sqrt(mode RoundingMode) (Decimal6, error) {
+	x, err := sqrtScaled(int64(this), 6, mode)
+	return Decimal6(x), err
+}
+
+// Statistics functions compute exactly with big.Int sums, rounding once at the end.
+// Population functions divide by n, Sample functions by n - 1.
+// They return ErrLength if x and y lengths differ, ErrEmpty if there are no values,
+// and ErrDivisionByZero for a sample of 1 value.
+
+// coMoment returns n * sum(x*y) - sum(x) * sum(y), which is n^2 times the population covariance.
+func coMoment[T, U Decimal](x []T, y []U) *big.Int {
+	sumX, sumY, sumXY := new(big.Int), new(big.Int), new(big.Int)
+	for i := range x {
+		a, b := big.NewInt(int64(x[i])), big.NewInt(int64(y[i]))
+		sumX.Add(sumX, a)
+		sumY.Add(sumY, b)
+		sumXY.Add(sumXY, b.Mul(a, b))
+	}
+	m := sumXY.Mul(sumXY, big.NewInt(int64(len(x))))
+	return m.Sub(m, sumX.Mul(sumX, sumY))
+}
+
+// divisor returns n^2 for a population or n * (n - 1) for a sample.
+func divisor(n int, sample bool) (*big.Int, error) {
+	if n == 0 {
+		return nil, ErrEmpty
+	}
+	d := big.NewInt(int64(n))
+	if sample {
+		if n == 1 {
+			return nil, ErrDivisionByZero
+		}
+		return d.Mul(d, big.NewInt(int64(n-1))), nil
+	}
+	return d.Mul(d, d), nil
+}
+
+func covariance[T Decimal](x, y []T, sample bool, mode RoundingMode) (T, error) {
+	if len(x) != len(y) {
+		return 0, ErrLength
+	}
+	d, err := divisor(len(x), sample)
+	if err != nil {
+		return 0, err
+	}
+	var zero T
+	d.Mul(d, big.NewInt(pow10[zero.Places()]))
+	c := bigQuo(coMoment(x, y), d, mode)
+	if !c.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return T(c.Int64()), nil
+}
+
+func stdDev[T Decimal](values []T, sample bool, mode RoundingMode) (T, error) {
+	d, err := divisor(len(values), sample)
+	if err != nil {
+		return 0, err
+	}
+	// variance in units is m / d / 10^places, so the deviation in units is sqrt(m / d)
+	s := sqrtRat(coMoment(values, values), d, false, mode)
+	if !s.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return T(s.Int64()), nil
+}
+
+// Variance returns the population variance of values, rounded using mode.
+func Variance[T Decimal](values []T, mode RoundingMode) (T, error) {
+	return covariance(values, values, false, mode)
+}
+
+// SampleVariance returns the sample variance of values, rounded using mode.
+func SampleVariance[T Decimal](values []T, mode RoundingMode) (T, error) {
+	return covariance(values, values, true, mode)
+}
+
+// StdDev returns the population standard deviation of values, correctly rounded using mode.
+func StdDev[T Decimal](values []T, mode RoundingMode) (T, error) {
+	return stdDev(values, false, mode)
+}
+
+// SampleStdDev returns the sample standard deviation of values, correctly rounded using mode.
+func SampleStdDev[T Decimal](values []T, mode RoundingMode) (T, error) {
+	return stdDev(values, true, mode)
+}
+
+// Covariance returns the population covariance of x and y, rounded using mode.
+func Covariance[T Decimal](x, y []T, mode RoundingMode) (T, error) {
+	return covariance(x, y, false, mode)
+}
+
+// SampleCovariance returns the sample covariance of x and y, rounded using mode.
+func SampleCovariance[T Decimal](x, y []T, mode RoundingMode) (T, error) {
+	return covariance(x, y, true, mode)
+}
+
+// Correlation returns the Pearson correlation coefficient of x and y (-1 to 1),
+// correctly rounded to 6 decimal places using mode.
+// Returns ErrDivisionByZero if x or y has no variation.
+func Correlation[T, U Decimal](x []T, y []U, mode RoundingMode) (Decimal6, error) {
+	if len(x) != len(y) {
+		return 0, ErrLength
+	}
+	if len(x) == 0 {
+		return 0, ErrEmpty
+	}
+	c, a, b := coMoment(x, y), coMoment(x, x), coMoment(y, y)
+	if a.Sign() == 0 || b.Sign() == 0 {
+		return 0, ErrDivisionByZero
+	}
+	// r = c / sqrt(a * b), |r| * 10^6 = sqrt(c^2 * 10^12 / (a * b))
+	n := new(big.Int).Mul(c, c)
+	n.Mul(n, big.NewInt(1000000000000))
+	r := sqrtRat(n, a.Mul(a, b), c.Sign() < 0, mode)
+	return Decimal6(r.Int64()), nil
+}
@@ -0,0 +1,165 @@
+package decimal4
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// refSqrt returns sqrt(x) in units of places decimal places using big.Float,
+// rounded down and half up.
+func refSqrt(x int64, places int) (down, halfUp int64) {
+	f := new(big.Float).SetPrec(256).SetInt64(x)
+	f.Mul(f, new(big.Float).SetInt64(pow10[places]))
+	f.Sqrt(f)
+	down, _ = f.Int64()
+	halfUp, _ = f.Add(f, big.NewFloat(.5)).Int64()
+	return down, halfUp
+}
+
+func TestSqrt(t *testing.T) {
+	type input struct {
+		x                     float64
+		halfUp, down, ceiling float64
+	}
+	data := []input{
+		{0, 0, 0, 0},
+		{1, 1, 1, 1},
+		{2, 1.4142, 1.4142, 1.4143},
+		{.0001, .01, .01, .01},
+		{.0002, .0141, .0141, .0142},
+		{10, 3.1623, 3.1622, 3.1623},
+		{123456.789, 351.3642, 351.3641, 351.3642}, // 351.36418...
+	}
+	for i, v := range data {
+		halfUp, _ := New(v.x).Sqrt(RoundHalfUp)
+		down, _ := New(v.x).Sqrt(RoundDown)
+		ceiling, _ := New(v.x).Sqrt(RoundCeiling)
+		if halfUp != New(v.halfUp) || down != New(v.down) || ceiling != New(v.ceiling) {
+			t.Errorf("data[%d]: got %s %s %s", i, halfUp, down, ceiling)
+		}
+	}
+	if _, err := New(-1).Sqrt(RoundHalfUp); err != ErrDomain {
+		t.Error("expected ErrDomain, got", err)
+	}
+	if x, _ := NewDecimal6(2).Sqrt(RoundHalfUp); x != NewDecimal6(1.414214) {
+		t.Error("Decimal6 Sqrt should be 1.414214, but is", x)
+	}
+	if x, _ := Decimal4(1<<63 - 1).Sqrt(RoundDown); x != New(30370004.9997) {
+		t.Error("Sqrt of max should be 30370004.9997, but is", x)
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		x := rnd.Int63() >> uint(rnd.Intn(63))
+		down, halfUp := refSqrt(x, 4)
+		d, _ := Decimal4(x).Sqrt(RoundDown)
+		h, _ := Decimal4(x).Sqrt(RoundHalfUp)
+		if int64(d) != down || int64(h) != halfUp {
+			t.Fatalf("Decimal4(%d).Sqrt: got %d %d, big.Float %d %d", x, d, h, down, halfUp)
+		}
+		down, halfUp = refSqrt(x, 6)
+		d6, _ := Decimal6(x).Sqrt(RoundDown)
+		h6, _ := Decimal6(x).Sqrt(RoundHalfUp)
+		if int64(d6) != down || int64(h6) != halfUp {
+			t.Fatalf("Decimal6(%d).Sqrt: got %d %d, big.Float %d %d", x, d6, h6, down, halfUp)
+		}
+	}
+}
+
+func TestVariance(t *testing.T) {
+	var values []Decimal4
+	for _, v := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		values = append(values, New(v))
+	}
+	type result struct {
+		name string
+		got  Decimal4
+		err  error
+		want float64
+	}
+	variance, err1 := Variance(values, RoundHalfUp)
+	sampleVariance, err2 := SampleVariance(values, RoundHalfUp)
+	stdDev, err3 := StdDev(values, RoundHalfUp)
+	sampleStdDev, err4 := SampleStdDev(values, RoundHalfUp)
+	data := []result{
+		{"Variance", variance, err1, 4},
+		{"SampleVariance", sampleVariance, err2, 4.5714},
+		{"StdDev", stdDev, err3, 2},
+		{"SampleStdDev", sampleStdDev, err4, 2.1381},
+	}
+	for _, v := range data {
+		if v.err != nil || v.got != New(v.want) {
+			t.Errorf("%s should be %f, but is %s %v", v.name, v.want, v.got, v.err)
+		}
+	}
+
+	// sums of squares exceed int64
+	prices := []Decimal4{New(90000000), New(90000001), New(90000003)}
+	if sd, _ := StdDev(prices, RoundHalfUp); sd != New(1.2472) {
+		t.Error("StdDev should be 1.2472, but is", sd)
+	}
+	if _, err := Variance([]Decimal4{}, RoundHalfUp); err != ErrEmpty {
+		t.Error("expected ErrEmpty, got", err)
+	}
+	if _, err := SampleStdDev(values[:1], RoundHalfUp); err != ErrDivisionByZero {
+		t.Error("expected ErrDivisionByZero, got", err)
+	}
+	if _, err := Variance([]Decimal4{New(-900000000), New(900000000)}, RoundHalfUp); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+}
+
+func TestCovariance(t *testing.T) {
+	x := []Decimal4{New(1), New(2), New(3), New(4)}
+	y := []Decimal4{New(2), New(4.1), New(5.9), New(8.2)}
+	if c, _ := Covariance(x, y, RoundHalfUp); c != New(2.55) {
+		t.Error("Covariance should be 2.55, but is", c)
+	}
+	if c, _ := SampleCovariance(x, y, RoundHalfUp); c != New(3.4) {
+		t.Error("SampleCovariance should be 3.4, but is", c)
+	}
+	if _, err := Covariance(x, y[:3], RoundHalfUp); err != ErrLength {
+		t.Error("expected ErrLength, got", err)
+	}
+	if _, err := Covariance([]Decimal4{}, []Decimal4{}, RoundHalfUp); err != ErrEmpty {
+		t.Error("expected ErrEmpty, got", err)
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	x := []Decimal4{New(1), New(2), New(3), New(4)}
+	down := []Decimal6{NewDecimal6(8), NewDecimal6(6), NewDecimal6(4), NewDecimal6(2)}
+	if r, _ := Correlation(x, down, RoundHalfUp); r != NewDecimal6(-1) {
+		t.Error("Correlation should be -1, but is", r)
+	}
+	if _, err := Correlation(x, []Decimal4{1, 1, 1, 1}, RoundHalfUp); err != ErrDivisionByZero {
+		t.Error("expected ErrDivisionByZero, got", err)
+	}
+	if _, err := Correlation(x, down[:3], RoundHalfUp); err != ErrLength {
+		t.Error("expected ErrLength, got", err)
+	}
+	if _, err := Correlation([]Decimal4{}, []Decimal6{}, RoundHalfUp); err != ErrEmpty {
+		t.Error("expected ErrEmpty, got", err)
+	}
+
+	// compare with big.Float
+	rnd := rand.New(rand.NewSource(2))
+	for i := 0; i < 200; i++ {
+		n := 2 + rnd.Intn(20)
+		a, b := make([]Decimal4, n), make([]Decimal4, n)
+		for j := range a {
+			a[j] = Decimal4(rnd.Int63n(2000000000) - 1000000000)
+			b[j] = Decimal4(rnd.Int63n(2000000000) - 1000000000)
+		}
+		c, x2, y2 := coMoment(a, b), coMoment(a, a), coMoment(b, b)
+		f := new(big.Float).SetPrec(256).SetInt(x2.Mul(x2, y2))
+		f.Quo(new(big.Float).SetPrec(256).SetInt(c), f.Sqrt(f))
+		f.Mul(f, big.NewFloat(1000000))
+		want, _ := f.Int64() // truncated
+		got, _ := Correlation(a, b, RoundDown)
+		if int64(got) != want {
+			t.Fatalf("Correlation %v %v: got %d, big.Float %d", a, b, got, want)
+		}
+	}
+}
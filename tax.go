@@ -0,0 +1,224 @@
+package decimal4
+
+import (
+	"log"
+	"math/big"
+)
+
+// TaxRounding is the default rounding for tax amounts, used by ExtractTax, AddTax
+// and a TaxSet without Rounding. Change it only during initialization, it is not
+// safe to change while other goroutines calculate tax; use Rounding.ExtractTax,
+// Rounding.AddTax or TaxSet.Rounding for other roundings.
+var TaxRounding = Rounding{Places: 2, Mode: RoundHalfUp}
+
+// ExtractTax returns the net amount and tax contained in tax-inclusive gross, see Rounding.ExtractTax.
+// Tax is rounded using TaxRounding.
+func ExtractTax(gross Decimal4, rate Decimal6) (net, tax Decimal4) {
+	return TaxRounding.ExtractTax(gross, rate)
+}
+
+// AddTax returns the gross amount and tax for tax-exclusive net, see Rounding.AddTax.
+// Tax is rounded using TaxRounding.
+func AddTax(net Decimal4, rate Decimal6) (gross, tax Decimal4) {
+	return TaxRounding.AddTax(net, rate)
+}
+
+// ExtractTax returns the net amount and tax contained in tax-inclusive gross.
+// Tax = gross * rate / (1 + rate), rounded using r. Net + tax == gross.
+func (r Rounding) ExtractTax(gross Decimal4, rate Decimal6) (net, tax Decimal4) {
+	if rate == -1000000 {
+		log.Panic("Decimal4 ExtractTax by zero, gross=", gross, " rate=", rate)
+	}
+	tax, ok := r.mulDiv(int64(gross), int64(rate), 1000000+int64(rate))
+	if !ok {
+		overflow("Decimal4 ExtractTax", gross, rate)
+	}
+	return gross - tax, tax
+}
+
+// AddTax returns the gross amount and tax for tax-exclusive net.
+// Tax = net * rate, rounded using r. Net + tax == gross.
+func (r Rounding) AddTax(net Decimal4, rate Decimal6) (gross, tax Decimal4) {
+	tax, ok := r.mulDiv(int64(net), int64(rate), 1000000)
+	gross = net + tax
+	if !ok || (tax > 0 && gross < net) || (tax < 0 && gross > net) {
+		overflow("Decimal4 AddTax", net, rate)
+	}
+	return gross, tax
+}
+
+// Tax is one rate in a TaxSet.
+// A compound tax is charged on the net amount plus all taxes before it in the set.
+type Tax struct {
+	Code     string
+	Rate     Decimal6
+	Compound bool
+}
+
+// TaxAmount is the tax charged for one Tax.
+type TaxAmount struct {
+	Code   string   `json:"code"`
+	Rate   Decimal6 `json:"rate"`
+	Base   Decimal4 `json:"base"` // amount the rate was applied to
+	Amount Decimal4 `json:"amount"`
+}
+
+// TaxResult is the breakdown of an amount into net and taxes.
+// Net plus the sum of Taxes amounts always equals Gross.
+type TaxResult struct {
+	Net   Decimal4    `json:"net"`
+	Gross Decimal4    `json:"gross"`
+	Taxes []TaxAmount `json:"taxes"`
+}
+
+// Tax returns the total of all tax amounts.
+func (this TaxResult) Tax() Decimal4 {
+	var total Decimal4
+	for _, v := range this.Taxes {
+		total += v.Amount
+	}
+	return total
+}
+
+// TaxSet applies several taxes to an amount.
+// Taxes are applied in order, see Tax for compounding.
+// If Inclusive is true, amounts passed to Apply include tax.
+type TaxSet struct {
+	Taxes     []Tax
+	Inclusive bool
+	Rounding  *Rounding // for tax amounts, nil = TaxRounding
+}
+
+// rounding returns this.Rounding, or TaxRounding if it is nil.
+func (this TaxSet) rounding() Rounding {
+	if this.Rounding != nil {
+		return *this.Rounding
+	}
+	return TaxRounding
+}
+
+// Add returns the taxes charged on tax-exclusive net.
+// Each tax is rounded using this.Rounding; a compound tax uses the rounded taxes before it.
+func (this TaxSet) Add(net Decimal4) TaxResult {
+	r := this.rounding()
+	result := TaxResult{Net: net, Gross: net, Taxes: make([]TaxAmount, len(this.Taxes))}
+	for i, v := range this.Taxes {
+		base := net
+		if v.Compound {
+			base = result.Gross
+		}
+		_, amount := r.AddTax(base, v.Rate)
+		result.Taxes[i] = TaxAmount{Code: v.Code, Rate: v.Rate, Base: base, Amount: amount}
+		result.Gross += amount
+	}
+	return result
+}
+
+// Extract returns the taxes contained in tax-inclusive gross.
+// Each tax is rounded from its exact share of gross using this.Rounding,
+// and net is the remainder, so the breakdown always sums to gross.
+func (this TaxSet) Extract(gross Decimal4) TaxResult {
+	// Express each tax as a multiple of net: parallel taxes are rate * net,
+	// compound taxes are rate * (net + taxes before it).
+	million := big.NewRat(1000000, 1)
+	shares := make([]*big.Rat, len(this.Taxes))
+	total := big.NewRat(1, 1) // gross as a multiple of net
+	for i, v := range this.Taxes {
+		rate := new(big.Rat).Quo(big.NewRat(int64(v.Rate), 1), million)
+		base := big.NewRat(1, 1)
+		if v.Compound {
+			base.Set(total)
+		}
+		shares[i] = base.Mul(base, rate)
+		total.Add(total, shares[i])
+	}
+	if total.Sign() == 0 {
+		log.Panic("Decimal4 TaxSet Extract by zero, gross=", gross)
+	}
+	result := TaxResult{Net: gross, Gross: gross, Taxes: make([]TaxAmount, len(this.Taxes))}
+	netRat := new(big.Rat).Quo(big.NewRat(int64(gross), 1), total)
+	r := this.rounding()
+	for i, v := range this.Taxes {
+		amount, ok := r.ratRound(new(big.Rat).Mul(netRat, shares[i]))
+		if !ok {
+			overflow("Decimal4 TaxSet Extract", gross)
+		}
+		result.Taxes[i] = TaxAmount{Code: v.Code, Rate: v.Rate, Amount: Decimal4(amount)}
+		result.Net -= Decimal4(amount)
+	}
+	base := result.Net
+	for i, v := range this.Taxes {
+		if v.Compound {
+			result.Taxes[i].Base = base
+		} else {
+			result.Taxes[i].Base = result.Net
+		}
+		base += result.Taxes[i].Amount
+	}
+	return result
+}
+
+// Apply calls Extract if this.Inclusive, otherwise Add.
+func (this TaxSet) Apply(amount Decimal4) TaxResult {
+	if this.Inclusive {
+		return this.Extract(amount)
+	}
+	return this.Add(amount)
+}
+
+// TaxMethod determines where tax is rounded on a multi-line document.
+type TaxMethod int
+
+const (
+	PerLine TaxMethod = iota // tax rounded on each line, then summed
+	OnTotal                  // lines summed, then tax rounded once
+)
+
+// TaxReconciliation compares tax rounded per line with tax rounded on the total.
+type TaxReconciliation struct {
+	Lines      []TaxResult // each line taxed separately
+	PerLine    TaxResult   // sum of Lines
+	OnTotal    TaxResult   // tax applied once to the sum of the line amounts
+	Difference Decimal4    // PerLine.Tax() - OnTotal.Tax()
+}
+
+// Total returns the taxes for amounts, rounded using method.
+func (this TaxSet) Total(amounts []Decimal4, method TaxMethod) TaxResult {
+	if method == OnTotal {
+		var sum Decimal4
+		for _, v := range amounts {
+			sum += v
+		}
+		return this.Apply(sum)
+	}
+	total := TaxResult{Taxes: make([]TaxAmount, len(this.Taxes))}
+	for i, v := range this.Taxes {
+		total.Taxes[i] = TaxAmount{Code: v.Code, Rate: v.Rate}
+	}
+	for _, v := range amounts {
+		total.add(this.Apply(v))
+	}
+	return total
+}
+
+// Reconcile taxes amounts both per line and on the total, and reports the difference.
+func (this TaxSet) Reconcile(amounts []Decimal4) TaxReconciliation {
+	r := TaxReconciliation{Lines: make([]TaxResult, len(amounts))}
+	for i, v := range amounts {
+		r.Lines[i] = this.Apply(v)
+	}
+	r.PerLine = this.Total(amounts, PerLine)
+	r.OnTotal = this.Total(amounts, OnTotal)
+	r.Difference = r.PerLine.Tax() - r.OnTotal.Tax()
+	return r
+}
+
+// add sums x into this, x must be from the same TaxSet.
+func (this *TaxResult) add(x TaxResult) {
+	this.Net += x.Net
+	this.Gross += x.Gross
+	for i, v := range x.Taxes {
+		this.Taxes[i].Base += v.Base
+		this.Taxes[i].Amount += v.Amount
+	}
+}
@@ -0,0 +1,96 @@
+package decimal4
+
+import "testing"
+
+func TestExtractAddTax(t *testing.T) {
+	extract := []data{ // a = amount, b = rate, c = tax
+		{119, .19, 19},
+		{10, .2, 1.67},
+		{-10, .2, -1.67},
+		{0, .2, 0},
+		{99.99, .07, 6.54},
+	}
+	for i, v := range extract {
+		net, tax := ExtractTax(New(v.a), NewDecimal6(v.b))
+		if tax != New(v.c) || net+tax != New(v.a) {
+			t.Errorf("data[%d]: ExtractTax should be %f, but is %s, net %s", i, v.c, tax, net)
+		}
+	}
+	add := []data{
+		{100, .19, 19},
+		{8.33, .2, 1.67},
+		{-8.33, .2, -1.67},
+		{99.99, .07, 7},
+	}
+	for i, v := range add {
+		gross, tax := AddTax(New(v.a), NewDecimal6(v.b))
+		if tax != New(v.c) || gross-tax != New(v.a) {
+			t.Errorf("data[%d]: AddTax should be %f, but is %s, gross %s", i, v.c, tax, gross)
+		}
+	}
+
+	even := Rounding{Places: 2, Mode: RoundHalfEven}
+	if _, tax := even.AddTax(New(.25), NewDecimal6(.1)); tax != New(.02) {
+		t.Errorf("AddTax RoundHalfEven should be .02, but is %s", tax)
+	}
+	if _, tax := even.ExtractTax(New(.275), NewDecimal6(.1)); tax != New(.02) {
+		t.Errorf("ExtractTax RoundHalfEven should be .02, but is %s", tax)
+	}
+	set := TaxSet{Taxes: []Tax{{Code: "VAT", Rate: NewDecimal6(.1)}}, Rounding: &even}
+	if r := set.Add(New(.25)); r.Tax() != New(.02) {
+		t.Errorf("TaxSet RoundHalfEven should be .02, but is %s", r.Tax())
+	}
+	if r := set.Extract(New(.275)); r.Tax() != New(.02) {
+		t.Errorf("TaxSet Extract RoundHalfEven should be .02, but is %s", r.Tax())
+	}
+	if _, tax := AddTax(New(.25), NewDecimal6(.1)); tax != New(.03) {
+		t.Errorf("AddTax default should be .03, but is %s", tax)
+	}
+}
+
+func checkTaxResult(t *testing.T, name string, r TaxResult, net float64, taxes ...float64) {
+	total := r.Net
+	for i, v := range taxes {
+		if r.Taxes[i].Amount != New(v) {
+			t.Errorf("%s: tax %d should be %f, but is %s", name, i, v, r.Taxes[i].Amount)
+		}
+		total += r.Taxes[i].Amount
+	}
+	if r.Net != New(net) || total != r.Gross {
+		t.Errorf("%s: net should be %f, but is %s, gross %s, sum %s", name, net, r.Net, r.Gross, total)
+	}
+}
+
+func TestTaxSet(t *testing.T) {
+	parallel := TaxSet{Taxes: []Tax{{Code: "GST", Rate: NewDecimal6(.05)}, {Code: "PST", Rate: NewDecimal6(.07)}}}
+	checkTaxResult(t, "parallel Add", parallel.Add(New(100)), 100, 5, 7)
+	checkTaxResult(t, "parallel Extract", parallel.Extract(New(112)), 100, 5, 7)
+	checkTaxResult(t, "parallel Extract odd", parallel.Extract(New(10)), 8.92, .45, .63)
+
+	compound := TaxSet{Taxes: []Tax{{Code: "GST", Rate: NewDecimal6(.05)}, {Code: "QST", Rate: NewDecimal6(.095), Compound: true}}}
+	r := compound.Add(New(100))
+	checkTaxResult(t, "compound Add", r, 100, 5, 9.98)
+	if r.Taxes[1].Base != New(105) || r.Gross != New(114.98) {
+		t.Errorf("compound Add: base should be 105, but is %s, gross %s", r.Taxes[1].Base, r.Gross)
+	}
+	r = compound.Extract(New(114.98))
+	checkTaxResult(t, "compound Extract", r, 100, 5, 9.98)
+	if r.Taxes[1].Base != New(105) {
+		t.Errorf("compound Extract: base should be 105, but is %s", r.Taxes[1].Base)
+	}
+}
+
+func TestTaxReconcile(t *testing.T) {
+	set := TaxSet{Taxes: []Tax{{Code: "VAT", Rate: NewDecimal6(.1)}}}
+	lines := []Decimal4{New(.05), New(.05), New(.05)}
+	r := set.Reconcile(lines)
+	checkTaxResult(t, "PerLine", r.PerLine, .15, .03)
+	checkTaxResult(t, "OnTotal", r.OnTotal, .15, .02)
+	if r.Difference != New(.01) || len(r.Lines) != 3 {
+		t.Errorf("Difference should be .01, but is %s", r.Difference)
+	}
+
+	set.Inclusive = true
+	total := set.Total([]Decimal4{New(1.1), New(1.1), New(1.1)}, OnTotal)
+	checkTaxResult(t, "Inclusive OnTotal", total, 3, .3)
+}
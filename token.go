@@ -0,0 +1,208 @@
+package decimal4
+
+import (
+	"errors"
+	"math/big"
+)
+
+// MaxTokenDecimals is the largest number of decimal places of a TokenAmount.
+const MaxTokenDecimals = 18
+
+var (
+	ErrTokenDecimals = errors.New("decimal4: token decimals must be 0 to 18")
+	ErrTokenMismatch = errors.New("decimal4: token amounts have different decimals")
+)
+
+// TokenAmount is a 128-bit fixed-point amount with 0 to 18 decimal places,
+// for on-chain assets such as ERC-20 tokens (18 decimals: 1 token = 10^18 base units).
+// Limits at 18 decimals are about -170 to +170 quintillion (1.7e20) tokens.
+// Arithmetic is checked and returns ErrOverflow instead of panicking.
+type TokenAmount struct {
+	v        int128 // base units
+	decimals int
+}
+
+func validTokenDecimals(decimals int) bool {
+	return decimals >= 0 && decimals <= MaxTokenDecimals
+}
+
+func newTokenAmount(b *big.Int, decimals int) (TokenAmount, error) {
+	a, ok := int128FromBig(b)
+	if !ok {
+		return TokenAmount{}, ErrOverflow
+	}
+	return TokenAmount{a, decimals}, nil
+}
+
+// ParseToken returns the value of s with decimals places, for example ParseToken("1.5", 18).
+// Returns ErrParse if s is not a decimal number or has non-zero digits beyond decimals.
+func ParseToken(s string, decimals int) (TokenAmount, error) {
+	if !validTokenDecimals(decimals) {
+		return TokenAmount{}, ErrTokenDecimals
+	}
+	b, err := parseScaled(s, decimals)
+	if err != nil {
+		return TokenAmount{}, err
+	}
+	return newTokenAmount(b, decimals)
+}
+
+// TokenFromBaseUnits returns the amount of units base units (for example wei) with decimals places.
+func TokenFromBaseUnits(units *big.Int, decimals int) (TokenAmount, error) {
+	if !validTokenDecimals(decimals) {
+		return TokenAmount{}, ErrTokenDecimals
+	}
+	return newTokenAmount(units, decimals)
+}
+
+// TokenFromDecimal4 returns x with decimals places, rounded using mode if decimals < 4.
+func TokenFromDecimal4(x Decimal4, decimals int, mode RoundingMode) (TokenAmount, error) {
+	if !validTokenDecimals(decimals) {
+		return TokenAmount{}, ErrTokenDecimals
+	}
+	return newTokenAmount(rescaleBig(big.NewInt(int64(x)), 4, decimals, mode), decimals)
+}
+
+// TokenFromFiat returns the token amount worth amount at price (fiat per token), rounded using mode.
+func TokenFromFiat(amount Decimal4, price Decimal6, decimals int, mode RoundingMode) (TokenAmount, error) {
+	if !validTokenDecimals(decimals) {
+		return TokenAmount{}, ErrTokenDecimals
+	}
+	if price == 0 {
+		return TokenAmount{}, ErrDivisionByZero
+	}
+	// amount / 10^4 / (price / 10^6) * 10^decimals
+	n := new(big.Int).Mul(big.NewInt(int64(amount)), bigPow10(decimals+2))
+	return newTokenAmount(bigQuo(n, big.NewInt(int64(price)), mode), decimals)
+}
+
+// bigPow10 returns 10^n.
+func bigPow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// rescaleBig returns x with from places changed to to places, rounded using mode.
+func rescaleBig(x *big.Int, from, to int, mode RoundingMode) *big.Int {
+	if to >= from {
+		return new(big.Int).Mul(x, bigPow10(to-from))
+	}
+	return bigQuo(x, bigPow10(from-to), mode)
+}
+
+// Decimals returns the number of decimal places.
+func (this TokenAmount) Decimals() int {
+	return this.decimals
+}
+
+// BaseUnits returns this in base units (for example wei).
+func (this TokenAmount) BaseUnits() *big.Int {
+	return this.v.big()
+}
+
+// Sign returns -1, 0 or 1.
+func (this TokenAmount) Sign() int {
+	return this.v.sign()
+}
+
+// Cmp returns -1 if this < x, 0 if this == x, 1 if this > x. Decimals may differ.
+func (this TokenAmount) Cmp(x TokenAmount) int {
+	if this.decimals == x.decimals {
+		return this.v.cmp(x.v)
+	}
+	a := rescaleBig(this.BaseUnits(), this.decimals, MaxTokenDecimals, RoundDown)
+	b := rescaleBig(x.BaseUnits(), x.decimals, MaxTokenDecimals, RoundDown)
+	return a.Cmp(b)
+}
+
+// Rescale returns this with decimals places, rounded using mode if decimals is smaller.
+func (this TokenAmount) Rescale(decimals int, mode RoundingMode) (TokenAmount, error) {
+	if !validTokenDecimals(decimals) {
+		return TokenAmount{}, ErrTokenDecimals
+	}
+	return newTokenAmount(rescaleBig(this.BaseUnits(), this.decimals, decimals, mode), decimals)
+}
+
+// Add returns this + x, both must have the same decimals.
+func (this TokenAmount) Add(x TokenAmount) (TokenAmount, error) {
+	if this.decimals != x.decimals {
+		return TokenAmount{}, ErrTokenMismatch
+	}
+	a, ok := this.v.add(x.v)
+	if !ok {
+		return TokenAmount{}, ErrOverflow
+	}
+	return TokenAmount{a, this.decimals}, nil
+}
+
+// Sub returns this - x, both must have the same decimals.
+func (this TokenAmount) Sub(x TokenAmount) (TokenAmount, error) {
+	if this.decimals != x.decimals {
+		return TokenAmount{}, ErrTokenMismatch
+	}
+	a, ok := this.v.sub(x.v)
+	if !ok {
+		return TokenAmount{}, ErrOverflow
+	}
+	return TokenAmount{a, this.decimals}, nil
+}
+
+// Neg returns -this.
+func (this TokenAmount) Neg() (TokenAmount, error) {
+	if this.v.isMin() {
+		return TokenAmount{}, ErrOverflow
+	}
+	return TokenAmount{this.v.neg(), this.decimals}, nil
+}
+
+// MulInt returns this * x.
+func (this TokenAmount) MulInt(x int64) (TokenAmount, error) {
+	a, ok := this.v.mulDivSigned(x, 1, RoundDown)
+	if !ok {
+		return TokenAmount{}, ErrOverflow
+	}
+	return TokenAmount{a, this.decimals}, nil
+}
+
+// DivInt returns this / x, rounded to base units using mode.
+func (this TokenAmount) DivInt(x int64, mode RoundingMode) (TokenAmount, error) {
+	if x == 0 {
+		return TokenAmount{}, ErrDivisionByZero
+	}
+	a, ok := this.v.mulDivSigned(1, x, mode)
+	if !ok {
+		return TokenAmount{}, ErrOverflow
+	}
+	return TokenAmount{a, this.decimals}, nil
+}
+
+// Multiply6 returns this * x (for example a fee rate), rounded to base units using mode.
+func (this TokenAmount) Multiply6(x Decimal6, mode RoundingMode) (TokenAmount, error) {
+	a, ok := this.v.mulDivSigned(int64(x), 1000000, mode)
+	if !ok {
+		return TokenAmount{}, ErrOverflow
+	}
+	return TokenAmount{a, this.decimals}, nil
+}
+
+// Decimal4 returns this rounded to 4 decimal places using mode, or ErrOverflow if it does not fit.
+func (this TokenAmount) Decimal4(mode RoundingMode) (Decimal4, error) {
+	return FromBigInt(rescaleBig(this.BaseUnits(), this.decimals, 4, mode))
+}
+
+// Value returns the fiat value of this at price (fiat per token), rounded to 4 places using mode.
+func (this TokenAmount) Value(price Decimal6, mode RoundingMode) (Decimal4, error) {
+	// base units / 10^decimals * price / 10^6 * 10^4
+	n := new(big.Int).Mul(this.BaseUnits(), big.NewInt(int64(price)))
+	return FromBigInt(bigQuo(n, bigPow10(this.decimals+2), mode))
+}
+
+// String returns this with all decimal places.
+func (this TokenAmount) String() string {
+	return formatScaled(this.BaseUnits(), this.decimals, this.decimals)
+}
+
+// Fmt returns this formatted like Decimal4.Fmt, with width.precision (precision 0-9)
+// and comma thousands separators. Optional currency symbol is prefixed.
+func (this TokenAmount) Fmt(widthPrecision float64, currency ...string) string {
+	return fmtScaled(this.BaseUnits(), this.decimals, widthPrecision, currency)
+}
@@ -0,0 +1,109 @@
+package decimal4
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestTokenParseFormat(t *testing.T) {
+	eth, err := ParseToken("1.000000000000000001", 18)
+	if err != nil || eth.BaseUnits().String() != "1000000000000000001" || eth.String() != "1.000000000000000001" {
+		t.Errorf("ParseToken should be 1.000000000000000001, but is %s, %v", eth, err)
+	}
+	if _, err := ParseToken("1.0000000000000000001", 18); err != ErrParse {
+		t.Error("expected ErrParse, got", err)
+	}
+	if _, err := ParseToken("1", 19); err != ErrTokenDecimals {
+		t.Error("expected ErrTokenDecimals, got", err)
+	}
+	if _, err := ParseToken("170141183460469231732", 18); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+	if _, err := ParseToken("-170141183460469231731.687303715884105728", 18); err != nil {
+		t.Error("smallest value should parse, got", err)
+	}
+	large, _ := ParseToken("-1234567.891234567891234567", 18)
+	if s := large.Fmt(.4, "ETH "); s != "ETH -1,234,567.8912" {
+		t.Errorf("Fmt expected:ETH -1,234,567.8912   got:%s", s)
+	}
+	usdc, _ := TokenFromBaseUnits(new(big.Int).SetInt64(-2500000), 6)
+	if usdc.String() != "-2.500000" || usdc.Decimals() != 6 {
+		t.Errorf("TokenFromBaseUnits should be -2.500000, but is %s", usdc)
+	}
+}
+
+func TestTokenArithmetic(t *testing.T) {
+	a, _ := ParseToken("100.5", 18)
+	b, _ := ParseToken("0.000000000000000001", 18)
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "100.500000000000000001" {
+		t.Errorf("Add should be 100.500000000000000001, but is %s, %v", sum, err)
+	}
+	diff, _ := b.Sub(a)
+	if diff.String() != "-100.499999999999999999" || diff.Sign() != -1 {
+		t.Errorf("Sub should be -100.499999999999999999, but is %s", diff)
+	}
+	third, _ := a.DivInt(3, RoundDown)
+	if third.String() != "33.500000000000000000" {
+		t.Errorf("DivInt should be 33.5, but is %s", third)
+	}
+	fee, _ := b.Multiply6(NewDecimal6(.5), RoundHalfEven)
+	if fee.Sign() != 0 {
+		t.Errorf("Multiply6 should round .5 wei to 0, but is %s", fee)
+	}
+	fee, _ = a.Multiply6(NewDecimal6(-.003), RoundFloor)
+	if fee.String() != "-0.301500000000000000" {
+		t.Errorf("Multiply6 should be -0.3015, but is %s", fee)
+	}
+	max, _ := ParseToken("170000000000000000000", 18)
+	if _, err := max.MulInt(2); err != ErrOverflow {
+		t.Error("MulInt: expected ErrOverflow, got", err)
+	}
+	if _, err := max.Add(max); err != ErrOverflow {
+		t.Error("Add: expected ErrOverflow, got", err)
+	}
+	usdc, _ := ParseToken("100.5", 6)
+	if _, err := a.Add(usdc); err != ErrTokenMismatch {
+		t.Error("expected ErrTokenMismatch, got", err)
+	}
+	if a.Cmp(usdc) != 0 || b.Cmp(usdc) != -1 {
+		t.Error("Cmp across decimals failed")
+	}
+	if _, err := a.DivInt(0, RoundDown); err != ErrDivisionByZero {
+		t.Error("expected ErrDivisionByZero, got", err)
+	}
+}
+
+func TestTokenConversions(t *testing.T) {
+	eth, _ := ParseToken("1.23456789", 18)
+	if d4, err := eth.Decimal4(RoundHalfUp); err != nil || d4 != New(1.2346) {
+		t.Errorf("Decimal4 should be 1.2346, but is %s, %v", d4, err)
+	}
+	if d4, _ := eth.Decimal4(RoundDown); d4 != New(1.2345) {
+		t.Errorf("Decimal4 RoundDown should be 1.2345, but is %s", d4)
+	}
+	x, _ := TokenFromDecimal4(New(-1.2345), 18, RoundHalfUp)
+	if x.String() != "-1.234500000000000000" {
+		t.Errorf("TokenFromDecimal4 should be -1.2345, but is %s", x)
+	}
+	x, _ = TokenFromDecimal4(New(1.2345), 2, RoundHalfEven)
+	if x.String() != "1.23" {
+		t.Errorf("TokenFromDecimal4 to 2 decimals should be 1.23, but is %s", x)
+	}
+	value, err := eth.Value(NewDecimal6(3456.789012), RoundHalfUp)
+	if err != nil || value != New(4267.6407) { // 4267.640716...
+		t.Errorf("Value should be 4267.6407, but is %s, %v", value, err)
+	}
+	tokens, err := TokenFromFiat(New(100), NewDecimal6(3), 18, RoundDown)
+	if err != nil || tokens.String() != "33.333333333333333333" {
+		t.Errorf("TokenFromFiat should be 33.333333333333333333, but is %s, %v", tokens, err)
+	}
+	rescaled, _ := tokens.Rescale(6, RoundUp)
+	if rescaled.String() != "33.333334" {
+		t.Errorf("Rescale should be 33.333334, but is %s", rescaled)
+	}
+	whale, _ := ParseToken("100000000000000000000", 18)
+	if _, err := whale.Decimal4(RoundDown); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+}
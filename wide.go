@@ -0,0 +1,152 @@
+package decimal4
+
+import (
+	"log"
+	"math/big"
+)
+
+// Wide is a 128-bit value with 4 implied decimal places, like Decimal4.
+// Use it to accumulate totals that may exceed int64, then narrow back with Decimal4().
+// Limits are about -17 to +17 thousand trillion trillion (1.7e34).
+// Methods panic on overflow, like the Decimal4 methods.
+type Wide struct {
+	v int128
+}
+
+// NewWide returns x as a Wide.
+func NewWide(x Decimal4) Wide {
+	return Wide{int128From64(int64(x))}
+}
+
+// Decimal4 returns this as a Decimal4, or ErrOverflow if it does not fit.
+func (this Wide) Decimal4() (Decimal4, error) {
+	x, ok := this.v.int64()
+	if !ok {
+		return 0, ErrOverflow
+	}
+	return Decimal4(x), nil
+}
+
+// Sign returns -1, 0 or 1.
+func (this Wide) Sign() int {
+	return this.v.sign()
+}
+
+// Cmp returns -1 if this < x, 0 if this == x, 1 if this > x.
+func (this Wide) Cmp(x Wide) int {
+	return this.v.cmp(x.v)
+}
+
+// Neg returns -this.
+func (this Wide) Neg() Wide {
+	if this.v.isMin() {
+		overflow("Wide Neg", this)
+	}
+	return Wide{this.v.neg()}
+}
+
+// Add returns this + x.
+func (this Wide) Add(x Wide) Wide {
+	a, ok := this.v.add(x.v)
+	if !ok {
+		overflow("Wide Add", this, x)
+	}
+	return Wide{a}
+}
+
+// AddDecimal4 returns this + x.
+func (this Wide) AddDecimal4(x Decimal4) Wide {
+	return this.Add(NewWide(x))
+}
+
+// Sub returns this - x.
+func (this Wide) Sub(x Wide) Wide {
+	a, ok := this.v.sub(x.v)
+	if !ok {
+		overflow("Wide Sub", this, x)
+	}
+	return Wide{a}
+}
+
+// SubDecimal4 returns this - x.
+func (this Wide) SubDecimal4(x Decimal4) Wide {
+	return this.Sub(NewWide(x))
+}
+
+// Multiply returns this * x, rounded to 4 decimal places.
+func (this Wide) Multiply(x Decimal4) Wide {
+	a, ok := this.v.mulDivSigned(int64(x), 10000, RoundHalfUp)
+	if !ok {
+		overflow("Wide Multiply", this, x)
+	}
+	return Wide{a}
+}
+
+// Multiply6 returns this * x, rounded to 4 decimal places.
+// Parameter x is type Decimal6, providing up to 6 places precision.
+func (this Wide) Multiply6(x Decimal6) Wide {
+	a, ok := this.v.mulDivSigned(int64(x), 1000000, RoundHalfUp)
+	if !ok {
+		overflow("Wide Multiply6", this, x)
+	}
+	return Wide{a}
+}
+
+// Divide returns quotient of this / x rounded to 4 decimal places.
+func (this Wide) Divide(x Decimal4) Wide {
+	if x == 0 {
+		log.Panic("Wide Divide by zero, this=", this)
+	}
+	a, ok := this.v.mulDivSigned(10000, int64(x), RoundHalfUp)
+	if !ok {
+		overflow("Wide Divide", this, x)
+	}
+	return Wide{a}
+}
+
+// DivideInt returns quotient of this / x rounded to 4 decimal places.
+func (this Wide) DivideInt(x int) Wide {
+	if x == 0 {
+		log.Panic("Wide DivideInt by zero, this=", this)
+	}
+	a, ok := this.v.mulDivSigned(1, int64(x), RoundHalfUp)
+	if !ok {
+		overflow("Wide DivideInt", this, x)
+	}
+	return Wide{a}
+}
+
+// Big returns this as a big.Int in Decimal4 units (4 implied decimal places).
+func (this Wide) Big() *big.Int {
+	return this.v.big()
+}
+
+// WideFromBig returns b (in Decimal4 units) as a Wide, or ErrOverflow if it does not fit.
+func WideFromBig(b *big.Int) (Wide, error) {
+	a, ok := int128FromBig(b)
+	if !ok {
+		return Wide{}, ErrOverflow
+	}
+	return Wide{a}, nil
+}
+
+// ParseWide returns the value of s, for example "-1234567.8912".
+// Returns ErrParse if s is not a decimal number or has non-zero digits after 4 decimal places.
+func ParseWide(s string) (Wide, error) {
+	b, err := parseScaled(s, 4)
+	if err != nil {
+		return Wide{}, err
+	}
+	return WideFromBig(b)
+}
+
+// String returns this with 4 decimal places.
+func (this Wide) String() string {
+	return formatScaled(this.Big(), 4, 4)
+}
+
+// Fmt returns this formatted like Decimal4.Fmt, with width.precision (precision 0-9)
+// and comma thousands separators. Optional currency symbol is prefixed.
+func (this Wide) Fmt(widthPrecision float64, currency ...string) string {
+	return fmtScaled(this.Big(), 4, widthPrecision, currency)
+}
@@ -0,0 +1,107 @@
+package decimal4
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestWideAccumulate(t *testing.T) {
+	var total Wide
+	for i := 0; i < 1000; i++ {
+		total = total.AddDecimal4(math.MaxInt64)
+	}
+	if total.String() != "922337203685477580.7000" {
+		t.Errorf("total should be 922337203685477580.7000, but is %s", total)
+	}
+	if _, err := total.Decimal4(); err != ErrOverflow {
+		t.Error("expected ErrOverflow, got", err)
+	}
+	average, err := total.DivideInt(1000).Decimal4()
+	if err != nil || average != math.MaxInt64 {
+		t.Errorf("average should be %d, but is %d, %v", int64(math.MaxInt64), average, err)
+	}
+	for i := 0; i < 1000; i++ {
+		total = total.SubDecimal4(math.MaxInt64)
+	}
+	total = total.SubDecimal4(math.MinInt64)
+	if x, err := total.Neg().Decimal4(); err != nil || x != math.MinInt64 {
+		t.Errorf("narrowed value should be MinInt64, but is %d, %v", x, err)
+	}
+	if NewWide(-1).Cmp(NewWide(1)) != -1 || NewWide(-1).Sign() != -1 || (Wide{}).Sign() != 0 {
+		t.Error("Cmp or Sign failed")
+	}
+}
+
+func TestWideMultiplyDivide(t *testing.T) {
+	type input struct {
+		a string
+		b Decimal4
+		c string
+	}
+	data := []input{
+		{"0", 10000, "0.0000"},
+		{"1", 10000, "1.0000"},
+		{"-1.0001", 5000, "-0.5001"}, // -.50005 rounded away from zero
+		{"123456789012345678901234.5678", 20000, "246913578024691357802469.1356"},
+		{"-123456789012345678901234.5678", -5, "61728394506172839450.6173"},
+	}
+	for i, v := range data {
+		a, err := ParseWide(v.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c := a.Multiply(v.b); c.String() != v.c {
+			t.Errorf("data[%d]: Multiply should be %s, but is %s", i, v.c, c)
+		}
+	}
+	a, _ := ParseWide("1000000000000000000000")
+	if c := a.Divide(30000); c.String() != "333333333333333333333.3333" {
+		t.Errorf("Divide should be 333333333333333333333.3333, but is %s", c)
+	}
+	if c := a.Multiply6(-1500000); c.String() != "-1500000000000000000000.0000" {
+		t.Errorf("Multiply6 should be -1500000000000000000000.0000, but is %s", c)
+	}
+
+	// compare with big.Int reference
+	x, _ := ParseWide("98765432109876543210.9876")
+	for _, m := range []Decimal4{1, 3, 9999, -77777, 123456789, 99999999999} {
+		expect := new(big.Int).Mul(x.Big(), big.NewInt(int64(m)))
+		q, r := new(big.Int).QuoRem(expect, big.NewInt(10000), new(big.Int))
+		if r.CmpAbs(big.NewInt(5000)) >= 0 {
+			q.Add(q, big.NewInt(int64(r.Sign())))
+		}
+		if got := x.Multiply(m).Big(); got.Cmp(q) != 0 {
+			t.Errorf("Multiply %d should be %s, but is %s", m, q, got)
+		}
+	}
+}
+
+func TestWideParseFmt(t *testing.T) {
+	data := []struct {
+		input string
+		valid bool
+	}{
+		{"0", true}, {"-0.5", true}, {"+12.3400", true}, {".5", true}, {"5.", true},
+		{"1.23450", true}, {"1.23456", false}, {"", false}, {".", false}, {"1e5", false},
+		{"17014118346046923173168730371588410.5727", true},
+		{"17014118346046923173168730371588410.5728", false},
+		{"-17014118346046923173168730371588410.5728", true},
+		{"-17014118346046923173168730371588410.5729", false},
+	}
+	for _, v := range data {
+		if _, err := ParseWide(v.input); (err == nil) != v.valid {
+			t.Errorf("ParseWide(%q) error: %v", v.input, err)
+		}
+	}
+	w, _ := ParseWide("-12345678901234567890.1235")
+	if s := w.Fmt(.2, Dollar); s != "$-12,345,678,901,234,567,890.12" {
+		t.Errorf("Fmt expected:$-12,345,678,901,234,567,890.12   got:%s", s)
+	}
+	if s := NewWide(11111).Fmt(5.2); s != " 1.11" {
+		t.Errorf("Fmt expected: 1.11   got:%s", s)
+	}
+	if s := NewWide(12345600).Fmt(10.2, Dollar); s != " $1,234.56" {
+		t.Errorf("Fmt expected: $1,234.56   got:%s", s)
+	}
+}